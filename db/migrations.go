@@ -1,16 +1,315 @@
 package db
 
-import "coinkeeper/models"
+import (
+	"coinkeeper/models"
+	"fmt"
+	"gorm.io/gorm"
+	"math"
+)
 
 func Migrate() error {
+	if err := migrateLegacyCategories(); err != nil {
+		return err
+	}
+
 	err := dbConn.AutoMigrate(models.User{},
-		models.Income{},
-		models.Outcome{},
-		models.Expense{},
+		models.Category{},
 		models.Card{},
+		models.ExchangeRate{},
+		models.Transfer{},
+		models.Transaction{},
+		models.Budget{},
+		models.Goal{},
+		models.GoalContribution{},
+		models.RecurringRule{},
+		models.RecurringOccurrence{},
+		models.RefreshToken{},
+		models.RevokedToken{},
+		models.PasswordResetToken{},
+		models.RecoveryCode{},
+		models.TwoFactorChallenge{},
+		models.LoginThrottle{},
+		models.LoginAttempt{},
+		models.AuditEntry{},
 	)
 	if err != nil {
 		return err
 	}
+
+	if err = migrateLegacyTransactions(); err != nil {
+		return err
+	}
+	if err = migrateLegacyMoney(); err != nil {
+		return err
+	}
+	if err = migrateDeletedAt(); err != nil {
+		return err
+	}
+	if err = migrateGoalContributions(); err != nil {
+		return err
+	}
+	if err = migrateTransferLegs(); err != nil {
+		return err
+	}
+	if err = createListIndexes(); err != nil {
+		return err
+	}
+	if err = protectAuditEntries(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// protectAuditEntries запрещает изменять записи журнала изменений. Удалять их можно:
+// они удаляются вместе с аккаунтом пользователя.
+func protectAuditEntries() error {
+	err := dbConn.Exec(`
+		CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_entries is append-only';
+		END
+		$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return fmt.Errorf("cannot create audit_entries trigger function: %w", err)
+	}
+	if err = dbConn.Exec("DROP TRIGGER IF EXISTS audit_entries_no_update ON audit_entries").Error; err != nil {
+		return fmt.Errorf("cannot drop audit_entries trigger: %w", err)
+	}
+	err = dbConn.Exec(`
+		CREATE TRIGGER audit_entries_no_update BEFORE UPDATE ON audit_entries
+			FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only()`).Error
+	if err != nil {
+		return fmt.Errorf("cannot create audit_entries trigger: %w", err)
+	}
+	return nil
+}
+
+// listIndexes — составные индексы под фильтры и сортировки списков операций и карт.
+// Колонки models.Money встраиваются в несколько таблиц, поэтому индексы по ним
+// не описать тегами gorm и они создаются отдельно.
+var listIndexes = []struct {
+	Name       string
+	Definition string
+}{
+	{Name: "idx_transactions_user_type_date", Definition: "transactions (user_id, type, date DESC, id DESC) WHERE is_deleted = false"},
+	{Name: "idx_transactions_user_amount", Definition: "transactions (user_id, amount_minor) WHERE is_deleted = false"},
+	{Name: "idx_transactions_user_category", Definition: "transactions (user_id, category_id) WHERE is_deleted = false"},
+	{Name: "idx_transactions_user_card", Definition: "transactions (user_id, card_id) WHERE is_deleted = false"},
+	{Name: "idx_transactions_tags", Definition: "transactions USING gin (tags)"},
+	{Name: "idx_cards_user_created", Definition: "cards (user_id, created_at DESC, id DESC) WHERE is_deleted = false"},
+	{Name: "idx_cards_user_balance", Definition: "cards (user_id, balance_minor) WHERE is_deleted = false"},
+	{Name: "idx_transactions_trash", Definition: "transactions (user_id, deleted_at) WHERE is_deleted = true"},
+	{Name: "idx_cards_trash", Definition: "cards (user_id, deleted_at) WHERE is_deleted = true"},
+}
+
+func createListIndexes() error {
+	for _, index := range listIndexes {
+		if err := dbConn.Exec("CREATE INDEX IF NOT EXISTS " + index.Name + " ON " + index.Definition).Error; err != nil {
+			return fmt.Errorf("cannot create index %s: %w", index.Name, err)
+		}
+	}
+	return nil
+}
+
+// migrateLegacyCategories переименовывает общую таблицу outcome_categories в categories.
+// Внешние ключи outcomes, expenses и budgets следуют за переименованной таблицей, а её строки
+// остаются общими категориями расходов без владельца.
+func migrateLegacyCategories() error {
+	migrator := dbConn.Migrator()
+	if !migrator.HasTable("outcome_categories") || migrator.HasTable("categories") {
+		return nil
+	}
+	if err := migrator.RenameTable("outcome_categories", "categories"); err != nil {
+		return fmt.Errorf("cannot rename outcome_categories to categories: %w", err)
+	}
+	return nil
+}
+
+// legacyTransactionTables — прежние таблицы операций, тип операции, в который они переносятся,
+// и тип регулярного правила, чьи recurring_occurrences ссылаются на их строки
+var legacyTransactionTables = []struct {
+	Table         string
+	Type          string
+	RecurringType string
+}{
+	{Table: "incomes", Type: models.TransactionTypeIncome, RecurringType: models.RecurringTypeIncome},
+	{Table: "outcomes", Type: models.TransactionTypeExpense, RecurringType: models.RecurringTypeOutcome},
+	{Table: "expenses", Type: models.TransactionTypeExpense, RecurringType: models.RecurringTypeExpense},
+}
+
+// migrateLegacyTransactions переносит строки incomes, outcomes и expenses в transactions,
+// перенаправляет на новые строки recurring_occurrences и удаляет старые таблицы.
+// Каждая таблица переносится в своей транзакции, поэтому прерванная миграция продолжится
+// со следующего запуска. Затем для переводов без записи в transactions создаются записи типа transfer.
+func migrateLegacyTransactions() error {
+	migrator := dbConn.Migrator()
+	for _, t := range legacyTransactionTables {
+		if !migrator.HasTable(t.Table) {
+			continue
+		}
+
+		// Таблицы могли остаться от любой прежней версии: сумма во float-колонке amount,
+		// без карты у доходов, без категории у доходов
+		minor, currency := "amount_minor", "amount_currency"
+		if migrator.HasColumn(t.Table, "amount") {
+			minor = fmt.Sprintf("ROUND(COALESCE(amount, 0)::double precision::numeric * %d)::bigint",
+				int64(math.Pow10(models.CurrencyExponent(models.DefaultCurrency))))
+			currency = "'" + models.DefaultCurrency + "'"
+		}
+		cardID, categoryID := "NULL", "NULL"
+		if migrator.HasColumn(t.Table, "card_id") {
+			cardID = "NULLIF(card_id, 0)"
+		}
+		if migrator.HasColumn(t.Table, "category_id") {
+			categoryID = "NULLIF(category_id, 0)"
+		}
+
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(fmt.Sprintf(`
+				INSERT INTO transactions (type, amount_minor, amount_currency, description, card_id, category_id,
+					date, tags, legacy_table, legacy_id, user_id, created_at, updated_at, is_deleted)
+				SELECT ?, %s, %s, COALESCE(description, ''), %s, %s,
+					created_at, '[]', ?, id, user_id, created_at, updated_at, COALESCE(is_deleted, false)
+				FROM %s
+				ORDER BY id`, minor, currency, cardID, categoryID, t.Table),
+				t.Type, t.Table).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Exec(`
+				UPDATE recurring_occurrences SET transaction_id = transactions.id
+				FROM recurring_rules, transactions
+				WHERE recurring_rules.id = recurring_occurrences.rule_id AND recurring_rules.type = ?
+					AND transactions.legacy_table = ? AND transactions.legacy_id = recurring_occurrences.transaction_id`,
+				t.RecurringType, t.Table).Error
+			if err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(t.Table)
+		})
+		if err != nil {
+			return fmt.Errorf("cannot move %s to transactions: %w", t.Table, err)
+		}
+	}
+
+	err := dbConn.Exec(`
+		INSERT INTO transactions (type, amount_minor, amount_currency, description, card_id, transfer_id,
+			date, tags, user_id, created_at, updated_at, is_deleted)
+		SELECT ?, amount_minor, amount_currency, COALESCE(description, ''), from_card_id, id,
+			created_at, '[]', user_id, created_at, updated_at, status = ?
+		FROM transfers
+		WHERE NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.transfer_id = transfers.id)
+		ORDER BY id`,
+		models.TransactionTypeTransfer, models.TransferStatusCancelled).Error
+	if err != nil {
+		return fmt.Errorf("cannot add transfers to transactions: %w", err)
+	}
+	return nil
+}
+
+// legacyMoneyColumns — старые float-колонки сумм и колонки models.Money, в которые они переносятся
+var legacyMoneyColumns = []struct {
+	Table  string
+	Column string
+	Prefix string
+}{
+	{Table: "cards", Column: "balance", Prefix: "balance_"},
+}
+
+// migrateLegacyMoney переносит суммы из float-колонок в минорные единицы и удаляет старые колонки.
+// real сначала приводится к double precision: прямое приведение real::numeric оставляет
+// только 6 значащих цифр и округляет крупные суммы до целых.
+func migrateLegacyMoney() error {
+	for _, c := range legacyMoneyColumns {
+		if !dbConn.Migrator().HasColumn(c.Table, c.Column) {
+			continue
+		}
+
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(fmt.Sprintf(
+				`UPDATE %s SET %sminor = ROUND(COALESCE(%s, 0)::double precision::numeric * ?), %scurrency = ?`,
+				c.Table, c.Prefix, c.Column, c.Prefix,
+			), int64(math.Pow10(models.CurrencyExponent(models.DefaultCurrency))), models.DefaultCurrency).Error
+			if err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(c.Table, c.Column)
+		})
+		if err != nil {
+			return fmt.Errorf("cannot migrate %s.%s to minor units: %w", c.Table, c.Column, err)
+		}
+	}
+	return nil
+}
+
+// migrateDeletedAt проставляет время удаления записям, удалённым до появления корзины.
+// Точное время неизвестно, поэтому берётся время последнего изменения записи.
+func migrateDeletedAt() error {
+	for _, table := range []string{"transactions", "cards"} {
+		err := dbConn.Exec(fmt.Sprintf(
+			"UPDATE %s SET deleted_at = COALESCE(updated_at, created_at, now()) WHERE is_deleted = true AND deleted_at IS NULL", table,
+		)).Error
+		if err != nil {
+			return fmt.Errorf("cannot set %s.deleted_at: %w", table, err)
+		}
+	}
 	return nil
 }
+
+// migrateGoalContributions записывает в список операций движения по картам пополнений целей,
+// сделанных до того, как пополнения стали отражаться операциями: списание с карты-источника
+// и зачисление на карту цели
+func migrateGoalContributions() error {
+	err := dbConn.Exec(`
+		INSERT INTO transactions (type, amount_minor, amount_currency, description, card_id, goal_contribution_id,
+			date, time_zone, tags, user_id, created_at, updated_at, is_deleted)
+		SELECT 'transfer', movement.minor, movement.currency, goals.title, movement.card_id, contribution.id,
+			contribution.created_at, '', '[]', contribution.user_id, contribution.created_at, contribution.created_at, false
+		FROM goal_contributions contribution
+		JOIN goals ON goals.id = contribution.goal_id
+		CROSS JOIN LATERAL (
+			SELECT contribution.card_id, contribution.amount_minor, contribution.amount_currency
+			WHERE contribution.card_id IS NOT NULL
+			UNION ALL
+			SELECT goals.card_id, contribution.credited_minor, contribution.credited_currency
+			WHERE goals.card_id IS NOT NULL
+		) AS movement (card_id, minor, currency)
+		WHERE NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.goal_contribution_id = contribution.id)`).Error
+	if err != nil {
+		return fmt.Errorf("cannot record goal contributions as transactions: %w", err)
+	}
+	return nil
+}
+
+// migrateTransferLegs приводит записи переводов, сделанных до того, как в список операций
+// попадали обе карты: списание с карты-источника дополняется комиссией, а зачисление
+// на карту-получатель добавляется отдельной записью
+func migrateTransferLegs() error {
+	return dbConn.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			UPDATE transactions SET amount_minor = transfers.amount_minor + transfers.fee_minor
+			FROM transfers
+			WHERE transactions.transfer_id = transfers.id AND transactions.card_id = transfers.from_card_id
+				AND transactions.amount_minor <> transfers.amount_minor + transfers.fee_minor`).Error
+		if err != nil {
+			return fmt.Errorf("cannot add fees to transfer debits: %w", err)
+		}
+
+		err = tx.Exec(`
+			INSERT INTO transactions (type, amount_minor, amount_currency, description, card_id, transfer_id,
+				date, time_zone, tags, user_id, created_at, updated_at, is_deleted, deleted_at)
+			SELECT ?, credited_minor, credited_currency, COALESCE(description, ''), to_card_id, id,
+				created_at, '', '[]', user_id, created_at, updated_at, status = ?, cancelled_at
+			FROM transfers
+			WHERE NOT EXISTS (
+				SELECT 1 FROM transactions
+				WHERE transactions.transfer_id = transfers.id AND transactions.card_id = transfers.to_card_id)
+			ORDER BY id`,
+			models.TransactionTypeTransfer, models.TransferStatusCancelled).Error
+		if err != nil {
+			return fmt.Errorf("cannot record transfer credits as transactions: %w", err)
+		}
+		return nil
+	})
+}
@@ -11,4 +11,22 @@ var (
 	ErrRecordNotFound              = errors.New("ErrRecordNotFound")
 	ErrUserNotFound                = errors.New("ErrUserNotFound")
 	ErrSomethingWentWrong          = errors.New("ErrSomethingWentWrong")
+	ErrCurrencyMismatch            = errors.New("ErrCurrencyMismatch")
+	ErrExchangeRateNotFound        = errors.New("ErrExchangeRateNotFound")
+	ErrExchangeRateAlreadyExists   = errors.New("ErrExchangeRateAlreadyExists")
+	ErrTransferAlreadyCancelled    = errors.New("ErrTransferAlreadyCancelled")
+	ErrBudgetAlreadyExists         = errors.New("ErrBudgetAlreadyExists")
+	ErrInvalidStatement            = errors.New("ErrInvalidStatement")
+	ErrCategoryInUse               = errors.New("ErrCategoryInUse")
+	ErrInvalidToken                = errors.New("ErrInvalidToken")
+	ErrEmailUniquenessFailed       = errors.New("ErrEmailUniquenessFailed")
+	ErrIncorrectPassword           = errors.New("ErrIncorrectPassword")
+	ErrInvalidResetToken           = errors.New("ErrInvalidResetToken")
+	ErrInvalidTwoFactorCode        = errors.New("ErrInvalidTwoFactorCode")
+	ErrTwoFactorAlreadyEnabled     = errors.New("ErrTwoFactorAlreadyEnabled")
+	ErrTwoFactorNotEnabled         = errors.New("ErrTwoFactorNotEnabled")
+	ErrTwoFactorRequired           = errors.New("ErrTwoFactorRequired")
+	ErrTooManyLoginAttempts        = errors.New("ErrTooManyLoginAttempts")
+	ErrCardDeleted                 = errors.New("ErrCardDeleted")
+	ErrInvalidFields               = errors.New("ErrInvalidFields")
 )
@@ -0,0 +1,50 @@
+package errs
+
+import "strings"
+
+// Коды ошибок полей запроса
+const (
+	FieldRequired    = "required"     // Поле не заполнено
+	FieldInvalid     = "invalid"      // Значение не подходит по формату или не входит в допустимые
+	FieldInvalidType = "invalid_type" // Значение не того типа, например строка вместо числа
+	FieldTooSmall    = "too_small"    // Число или сумма меньше допустимого
+	FieldTooLong     = "too_long"     // Строка или список длиннее допустимого
+	FieldNotFound    = "not_found"    // Запись, на которую ссылается поле, не найдена
+)
+
+// FieldError — ошибка одного поля запроса. Field — путь к полю в JSON, например amount или tags[2].
+type FieldError struct {
+	Field   string `json:"field" example:"amount"`
+	Code    string `json:"code" example:"too_small"`
+	Message string `json:"message" example:"must be greater than 0"`
+}
+
+// ValidationError — ошибки полей запроса. Err — ErrValidationFailed, если запрос не удалось
+// разобрать, или ErrInvalidFields, если разобранные значения не прошли проверку.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fields = append(fields, field.Field+": "+field.Message)
+	}
+	return e.Err.Error() + ": " + strings.Join(fields, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewFieldError возвращает ErrInvalidFields с ошибкой одного поля
+func NewFieldError(field, code, message string) error {
+	return &ValidationError{Err: ErrInvalidFields, Fields: []FieldError{{Field: field, Code: code, Message: message}}}
+}
+
+// WithField дополняет ошибку err указанием поля, к которому она относится; статус ответа
+// по-прежнему определяет err
+func WithField(err error, field, code, message string) error {
+	return &ValidationError{Err: err, Fields: []FieldError{{Field: field, Code: code, Message: message}}}
+}
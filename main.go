@@ -5,6 +5,9 @@ import (
 	"coinkeeper/db"
 	"coinkeeper/logger"
 	"coinkeeper/pkg/controllers"
+	"coinkeeper/pkg/jobs"
+	"coinkeeper/pkg/mailer"
+	"coinkeeper/pkg/service"
 	"coinkeeper/server"
 	"context"
 	"fmt"
@@ -14,6 +17,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Часовые поясы операций не должны зависеть от наличия tzdata в контейнере
+	_ "time/tzdata"
 )
 
 // @title COIN_KEEPER API
@@ -39,6 +44,10 @@ func main() {
 		log.Fatal("Ошибка инициализации логгера: %s", err)
 	}
 
+	if err := mailer.Init(); err != nil {
+		log.Fatalf("Ошибка инициализации отправки писем: %s", err)
+	}
+
 	var err error
 	err = db.ConnectToDB()
 	if err != nil {
@@ -49,6 +58,13 @@ func main() {
 		log.Fatal("Ошибка миграции базы данных: %s", err)
 	}
 
+	if err = service.PromoteAdmins(configs.AppSettings.AuthParams.AdminUsernames); err != nil {
+		log.Fatalf("Ошибка назначения администраторов: %s", err)
+	}
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobs.Start(jobsCtx)
+
 	mainServer := new(server.Server)
 	go func() {
 		if err = mainServer.Run(configs.AppSettings.AppParams.PortRun, controllers.InitRoutes()); err != nil {
@@ -62,6 +78,10 @@ func main() {
 
 	fmt.Printf("\nНачало завершение программ\n")
 
+	// Останавливаем фоновые задачи до закрытия БД
+	stopJobs()
+	jobs.Wait()
+
 	//Close DB
 	if sqlDB, err := db.GetDBConn().DB(); err == nil {
 		if err := sqlDB.Close(); err != nil {
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Действия, записываемые в журнал изменений
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// Виды записей журнала изменений. Доходы, расходы и траты хранятся в transactions
+// и журналируются как transaction, их вид виден по полю type снимка.
+const (
+	AuditEntityTransaction = "transaction"
+	AuditEntityCard        = "card"
+	AuditEntityTransfer    = "transfer"
+	AuditEntityCategory    = "category"
+)
+
+// AuditEntry — запись журнала изменений финансовых данных. Записи только добавляются:
+// изменение строк запрещено триггером, удаляются они лишь вместе с аккаунтом.
+// Before и After — снимки записи до и после изменения (у создания нет Before, у удаления — After),
+// Changes — изменившиеся поля изменённой записи в виде {"поле": {"before": ..., "after": ...}}.
+type AuditEntry struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserID     uint      `json:"-" gorm:"not null;index:idx_audit_entries_user_created,priority:1"` // Владелец записи
+	ActorID    uint      `json:"actor_id" gorm:"not null"`                                          // Кто внёс изменение
+	Action     string    `json:"action" gorm:"size:16;not null" example:"update"`
+	EntityType string    `json:"entity_type" gorm:"size:32;not null;index:idx_audit_entries_entity,priority:1" example:"transaction"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index:idx_audit_entries_entity,priority:2"`
+	Before     AuditData `json:"before" gorm:"type:jsonb" swaggertype:"object"`
+	After      AuditData `json:"after" gorm:"type:jsonb" swaggertype:"object"`
+	Changes    AuditData `json:"changes" gorm:"type:jsonb" swaggertype:"object"`
+	IPAddress  string    `json:"ip_address" gorm:"size:64;not null;default:''"`
+	RequestID  string    `json:"request_id" gorm:"size:64;not null;default:''"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null;index:idx_audit_entries_user_created,priority:2"`
+}
+
+// AuditChange — значение поля до и после изменения
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditMeta — кто и откуда вносит изменение. Фоновые задачи действуют от имени владельца данных
+// и указывают вместо идентификатора запроса имя задачи.
+type AuditMeta struct {
+	ActorID   uint
+	IPAddress string
+	RequestID string
+}
+
+// AuditFilter — условия выборки журнала изменений; пустые поля не ограничивают выборку
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uint
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditData — JSON-документ в jsonb-колонке; пустое значение хранится как NULL
+type AuditData json.RawMessage
+
+func (d AuditData) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+func (d *AuditData) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = nil
+	case []byte:
+		*d = append(AuditData(nil), v...)
+	case string:
+		*d = AuditData(v)
+	default:
+		return errors.New("unsupported audit data value")
+	}
+	return nil
+}
+
+func (d AuditData) MarshalJSON() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("null"), nil
+	}
+	return d, nil
+}
+
+func (d *AuditData) UnmarshalJSON(data []byte) error {
+	*d = append(AuditData(nil), data...)
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	BudgetStatusWithin    = "within"
+	BudgetStatusNearLimit = "near_limit"
+	BudgetStatusOverspent = "overspent"
+)
+
+// Budget — месячный лимит расходов пользователя по категории
+type Budget struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Category   Category  `json:"-" gorm:"foreignKey:CategoryID;references:ID"`
+	CategoryID uint      `json:"category_id" gorm:"not null;uniqueIndex:idx_budgets_period"`
+	Year       int       `json:"year" gorm:"not null;uniqueIndex:idx_budgets_period" example:"2024"`
+	Month      int       `json:"month" gorm:"not null;uniqueIndex:idx_budgets_period" example:"5"`
+	Limit      Money     `json:"limit" gorm:"embedded;embeddedPrefix:limit_"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID     uint      `json:"-" gorm:"uniqueIndex:idx_budgets_period"`
+	CreatedAt  time.Time `json:"-"`
+	UpdatedAt  time.Time `json:"-"`
+}
+
+// BudgetStatus — сколько потрачено по бюджету за его месяц и сколько осталось
+type BudgetStatus struct {
+	Budget     Budget  `json:"budget"`
+	Spent      Money   `json:"spent"`
+	Remaining  Money   `json:"remaining"`
+	Percentage float64 `json:"percentage"`
+	Status     string  `json:"status"`
+}
+
+// BudgetInput — тело запроса на создание или замену бюджета; лимит без валюты задаётся
+// в базовой валюте пользователя
+type BudgetInput struct {
+	CategoryID uint  `json:"category_id" binding:"required"`
+	Year       int   `json:"year" binding:"required,gte=1" example:"2024"`
+	Month      int   `json:"month" binding:"required,gte=1,lte=12" example:"5"`
+	Limit      Money `json:"limit" binding:"required,gt=0"`
+}
+
+func (in BudgetInput) Budget() Budget {
+	return Budget{CategoryID: in.CategoryID, Year: in.Year, Month: in.Month, Limit: in.Limit}
+}
@@ -5,11 +5,57 @@ import "time"
 type Card struct {
 	ID          uint      `json:"id" gorm:"primary_key"`
 	CardNumber  string    `json:"card_number"`
-	Balance     float32   `json:"balance" gorm:"not null"`
+	Balance     Money     `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
 	Description string    `json:"description"`
 	User        User      `json:"-" gorm:"foreignKey:UserID;references:ID"` // Внешний ключ к User
 	UserID      uint      `json:"user_id"`
 	CreatedAt   time.Time `json:"-"`
 	UpdatedAt   time.Time `json:"-"`
 	IsDeleted   bool      `json:"-" gorm:"default:false"`
+	// DeletedAt — когда карта попала в корзину
+	DeletedAt *time.Time `json:"-"`
+}
+
+// CardInput — тело запроса на создание карты; без валюты баланса карта открывается в валюте по умолчанию
+type CardInput struct {
+	CardNumber  string `json:"card_number" binding:"required,notblank,max=32" example:"4444 **** **** 1234"`
+	Balance     Money  `json:"balance"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+func (in CardInput) Card() Card {
+	return Card{CardNumber: in.CardNumber, Balance: in.Balance, Description: in.Description}
+}
+
+// CardBalanceInput — тело запроса на изменение баланса карты: Amount прибавляется к балансу,
+// отрицательная сумма списывается. CardID передают прежние клиенты, он должен совпадать с картой из пути.
+type CardBalanceInput struct {
+	CardID uint  `json:"card_id"`
+	Amount Money `json:"amount" binding:"required"`
+}
+
+// CardFilter — условия выборки карт; пустые поля не ограничивают выборку.
+// From и To ограничивают дату создания карты, MinBalance и MaxBalance — баланс в минорных единицах.
+type CardFilter struct {
+	IDs        []uint
+	From       *time.Time
+	To         *time.Time
+	MinBalance *int64
+	MaxBalance *int64
+	Currency   string
+}
+
+// CardBalance — баланс карты в её собственной валюте и в пересчёте на запрошенную валюту
+type CardBalance struct {
+	CardID     uint   `json:"card_id"`
+	CardNumber string `json:"card_number"`
+	Balance    Money  `json:"balance"`
+	Converted  Money  `json:"converted"`
+}
+
+// CardsBalance — суммарный баланс всех карт пользователя в одной валюте
+type CardsBalance struct {
+	Currency string        `json:"currency"`
+	Total    Money         `json:"total"`
+	Cards    []CardBalance `json:"cards"`
 }
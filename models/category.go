@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	CategoryTypeIncome  = "income"
+	CategoryTypeOutcome = "outcome"
+)
+
+// Category — категория доходов или расходов. Категории пользователя могут быть вложенными
+// через ParentID. Категории без UserID остались от общей таблицы outcome_categories:
+// они видны всем пользователям, но изменять их нельзя.
+type Category struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Title     string     `json:"title" gorm:"not null"`
+	Type      string     `json:"type" gorm:"size:16;not null;default:'outcome'" example:"outcome"`
+	Parent    *Category  `json:"-" gorm:"foreignKey:ParentID;references:ID"`
+	ParentID  *uint      `json:"parent_id" gorm:"index"`
+	Icon      string     `json:"icon" example:"cart"`
+	Color     string     `json:"color" example:"#4CAF50"`
+	User      User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID    *uint      `json:"user_id" gorm:"index"`
+	Children  []Category `json:"children,omitempty" gorm:"-"`
+	CreatedAt time.Time  `json:"-"`
+	UpdatedAt time.Time  `json:"-"`
+}
+
+// CategoryInput — тело запроса на создание или изменение категории; без типа создаётся
+// категория расходов, при изменении тип не меняется
+type CategoryInput struct {
+	Title    string `json:"title" binding:"required,notblank,max=255" example:"Groceries"`
+	Type     string `json:"type" binding:"max=16" example:"outcome"`
+	ParentID *uint  `json:"parent_id" binding:"omitempty,gt=0"`
+	Icon     string `json:"icon" binding:"max=64" example:"cart"`
+	Color    string `json:"color" binding:"max=16" example:"#4CAF50"`
+}
+
+func (in CategoryInput) Category() Category {
+	return Category{Title: in.Title, Type: in.Type, ParentID: in.ParentID, Icon: in.Icon, Color: in.Color}
+}
+
+// CategoryMerge — категория, в которую переносятся операции объединяемой категории
+type CategoryMerge struct {
+	TargetID uint `json:"target_id" binding:"required"`
+}
@@ -5,6 +5,12 @@ type Configs struct {
 	AppParams      AppParams      `json:"app_params"`
 	PostgresParams PostgresParams `json:"postgres_params"`
 	AuthParams     AuthParams     `json:"auth_params"`
+	JobParams      JobParams      `json:"job_params"`
+	MailParams     MailParams     `json:"mail_params"`
+	// LoginProtectionParams — пороги защиты входа от подбора пароля
+	LoginProtectionParams LoginProtectionParams `json:"login_protection_params"`
+	AccountParams         AccountParams         `json:"account_params"`
+	TrashParams           TrashParams           `json:"trash_params"`
 }
 
 type LogParams struct {
@@ -26,6 +32,8 @@ type AppParams struct {
 	AppVersion string `json:"app_version"`
 	PortRun    string `json:"port_run"`
 	GinMode    string `json:"gin_mode"`
+	// TrustedProxies — адреса прокси, которым доверяется X-Forwarded-For при определении IP клиента
+	TrustedProxies []string `json:"trusted_proxies"`
 }
 
 type PostgresParams struct {
@@ -36,6 +44,67 @@ type PostgresParams struct {
 }
 
 type AuthParams struct {
-	JwtSecretKey  string `json:"jwt_secret_key"`
-	JwtTtlMinutes int    `json:"jwt_ttl_minutes"`
+	JwtSecretKey    string `json:"jwt_secret_key"`
+	JwtTtlMinutes   int    `json:"jwt_ttl_minutes"`
+	RefreshTtlHours int    `json:"refresh_ttl_hours"`
+	// PasswordResetTtlMinutes — время жизни токена сброса пароля
+	PasswordResetTtlMinutes int `json:"password_reset_ttl_minutes"`
+	// RequireTwoFactor обязывает всех пользователей подключить двухфакторную аутентификацию
+	RequireTwoFactor bool `json:"require_two_factor"`
+	// TotpIssuer — имя сервиса в приложении-аутентификаторе
+	TotpIssuer string `json:"totp_issuer"`
+	// TwoFactorChallengeTtlMinutes — сколько действует вход, ожидающий второго фактора
+	TwoFactorChallengeTtlMinutes int `json:"two_factor_challenge_ttl_minutes"`
+	// AdminUsernames — пользователи, которые при запуске получают роль admin
+	AdminUsernames []string `json:"admin_usernames"`
+}
+
+type JobParams struct {
+	RecurringIntervalSeconds    int `json:"recurring_interval_seconds"`
+	TokenCleanupIntervalSeconds int `json:"token_cleanup_interval_seconds"`
+	LoginCleanupIntervalSeconds int `json:"login_cleanup_interval_seconds"`
+	AccountPurgeIntervalSeconds int `json:"account_purge_interval_seconds"`
+	TrashPurgeIntervalSeconds   int `json:"trash_purge_interval_seconds"`
+}
+
+// MailParams — настройки отправки писем. Пароль SMTP берётся из переменной окружения SMTP_PASSWORD.
+type MailParams struct {
+	// Driver — smtp, file (письма сохраняются в Directory) или log (письма пишутся в лог)
+	Driver       string `json:"driver"`
+	From         string `json:"from"`
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     string `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	Directory    string `json:"directory"`
+	// ResetPasswordURL — адрес формы сброса пароля, токен передаётся в параметре token
+	ResetPasswordURL string `json:"reset_password_url"`
+}
+
+// LoginProtectionParams — пороги защиты входа. Первые *FreeAttempts неудачных входов подряд
+// проходят без задержки, после каждого следующего вход блокируется на BackoffBaseSeconds,
+// удваивая паузу до BackoffMaxSeconds. После *LockoutThreshold неудач вход блокируется
+// на LockoutMinutes. Счётчик сбрасывается успешным входом (для логина) или если неудач
+// не было FailureWindowMinutes.
+type LoginProtectionParams struct {
+	UsernameFreeAttempts     int `json:"username_free_attempts"`
+	UsernameLockoutThreshold int `json:"username_lockout_threshold"`
+	IPFreeAttempts           int `json:"ip_free_attempts"`
+	IPLockoutThreshold       int `json:"ip_lockout_threshold"`
+	BackoffBaseSeconds       int `json:"backoff_base_seconds"`
+	BackoffMaxSeconds        int `json:"backoff_max_seconds"`
+	LockoutMinutes           int `json:"lockout_minutes"`
+	FailureWindowMinutes     int `json:"failure_window_minutes"`
+	// AttemptRetentionDays — сколько дней хранится журнал неудачных входов
+	AttemptRetentionDays int `json:"attempt_retention_days"`
+}
+
+type AccountParams struct {
+	// DeletionGraceDays — через сколько дней после удаления учётная запись и все её данные
+	// удаляются окончательно
+	DeletionGraceDays int `json:"deletion_grace_days"`
+}
+
+type TrashParams struct {
+	// RetentionDays — через сколько дней после удаления записи из корзины удаляются окончательно
+	RetentionDays int `json:"retention_days"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ExchangeRate — локально сохранённый курс пользователя: 1 BaseCurrency = Rate QuoteCurrency
+type ExchangeRate struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	BaseCurrency  string    `json:"base_currency" gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_pair"`
+	QuoteCurrency string    `json:"quote_currency" gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_pair"`
+	Rate          string    `json:"rate" gorm:"type:numeric(24,12);not null" example:"10.95"`
+	User          User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID        uint      `json:"-" gorm:"uniqueIndex:idx_exchange_rates_pair"`
+	CreatedAt     time.Time `json:"-"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ExchangeRateInput — тело запроса на создание или замену курса: 1 BaseCurrency = Rate QuoteCurrency
+type ExchangeRateInput struct {
+	BaseCurrency  string `json:"base_currency" binding:"required,len=3" example:"USD"`
+	QuoteCurrency string `json:"quote_currency" binding:"required,len=3" example:"TJS"`
+	Rate          string `json:"rate" binding:"required,notblank,max=32" example:"10.95"`
+}
+
+func (in ExchangeRateInput) ExchangeRate() ExchangeRate {
+	return ExchangeRate{BaseCurrency: in.BaseCurrency, QuoteCurrency: in.QuoteCurrency, Rate: in.Rate}
+}
@@ -2,21 +2,76 @@ package models
 
 import "time"
 
+// Expense — трата по карте в прежнем формате /api/expenses; хранится как Transaction
+// с типом expense и картой
 type Expense struct {
-	ID          uint    `json:"id" gorm:"primary_key"`
-	Amount      float32 `json:"amount"`
-	Description string  `json:"description"`
-
-	Card   Card `json:"-" gorm:"foreignKey:CardID;references:ID"`
-	CardID uint `json:"card_id"`
-
-	Category   OutcomeCategory `json:"-" gorm:"foreignKey:CategoryID;references:ID"`
-	CategoryID uint            `json:"category_id"`
-
-	User   User `json:"-" gorm:"foreignKey:UserID;references:ID"`
-	UserID uint `json:"user_id"`
+	ID          uint   `json:"id"`
+	Amount      Money  `json:"amount"`
+	Description string `json:"description"`
+	CardID      uint   `json:"card_id"`
+	CategoryID  uint   `json:"category_id"`
+	UserID      uint   `json:"user_id"`
 
+	Date      time.Time `json:"date"`      // Когда совершена трата; по умолчанию — момент создания
+	TimeZone  string    `json:"time_zone"` // IANA-зона даты (необязательно)
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	IsDeleted bool      `json:"is_deleted"`
 }
+
+func ExpenseFromTransaction(t Transaction) Expense {
+	expense := Expense{
+		ID:          t.ID,
+		Amount:      t.Amount,
+		Description: t.Description,
+		UserID:      t.UserID,
+		Date:        t.Date,
+		TimeZone:    t.TimeZone,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		IsDeleted:   t.IsDeleted,
+	}
+	if t.CardID != nil {
+		expense.CardID = *t.CardID
+	}
+	if t.CategoryID != nil {
+		expense.CategoryID = *t.CategoryID
+	}
+	return expense
+}
+
+func (e Expense) Transaction() Transaction {
+	cardID, categoryID := e.CardID, e.CategoryID
+	return Transaction{
+		ID:          e.ID,
+		Type:        TransactionTypeExpense,
+		Description: e.Description,
+		Amount:      e.Amount,
+		CardID:      &cardID,
+		CategoryID:  &categoryID,
+		Date:        e.Date,
+		TimeZone:    e.TimeZone,
+		UserID:      e.UserID,
+	}
+}
+
+// ExpenseInput — тело запроса на создание или замену траты по карте
+type ExpenseInput struct {
+	Amount      Money     `json:"amount" binding:"required,gt=0"`
+	Description string    `json:"description" binding:"required,notblank,max=255"`
+	CardID      uint      `json:"card_id" binding:"required"`
+	CategoryID  uint      `json:"category_id" binding:"required"`
+	Date        time.Time `json:"date" example:"2024-05-01T12:00:00+05:00"`
+	TimeZone    string    `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+}
+
+func (in ExpenseInput) Expense() Expense {
+	return Expense{
+		Amount:      in.Amount,
+		Description: in.Description,
+		CardID:      in.CardID,
+		CategoryID:  in.CategoryID,
+		Date:        in.Date,
+		TimeZone:    in.TimeZone,
+	}
+}
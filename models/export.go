@@ -0,0 +1,21 @@
+package models
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatXLSX = "xlsx"
+)
+
+// Наборы данных, которые можно выгрузить через /api/export
+const (
+	ExportDatasetTransactions = "transactions"
+	ExportDatasetCards        = "cards"
+	ExportDatasetCategories   = "categories"
+)
+
+// ExportDatasets — все наборы данных в порядке выгрузки
+var ExportDatasets = []string{
+	ExportDatasetTransactions,
+	ExportDatasetCards,
+	ExportDatasetCategories,
+}
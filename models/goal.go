@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// Goal — цель накоплений. Если указана карта, накопления зачисляются на неё
+// и валюта цели совпадает с валютой карты.
+type Goal struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Title       string     `json:"title" gorm:"not null"`
+	Description string     `json:"description"`
+	Target      Money      `json:"target" gorm:"embedded;embeddedPrefix:target_"`
+	Saved       Money      `json:"saved" gorm:"embedded;embeddedPrefix:saved_"`
+	Deadline    *time.Time `json:"deadline"`
+	Card        Card       `json:"-" gorm:"foreignKey:CardID;references:ID"`
+	CardID      *uint      `json:"card_id"`
+	User        User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID      uint       `json:"-" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"-"`
+	IsDeleted   bool       `json:"-" gorm:"default:false"`
+}
+
+// GoalContribution — пополнение цели. Amount списывается с карты-источника (если указана)
+// в её валюте, Credited — та же сумма в валюте цели.
+type GoalContribution struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Goal      Goal      `json:"-" gorm:"foreignKey:GoalID;references:ID"`
+	GoalID    uint      `json:"goal_id" gorm:"index"`
+	Card      Card      `json:"-" gorm:"foreignKey:CardID;references:ID"`
+	CardID    *uint     `json:"card_id"`
+	Amount    Money     `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Credited  Money     `json:"credited" gorm:"embedded;embeddedPrefix:credited_"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint      `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GoalProgress — прогресс накоплений по цели
+type GoalProgress struct {
+	Goal                Goal       `json:"goal"`
+	Percentage          float64    `json:"percentage"`
+	Remaining           Money      `json:"remaining"`
+	RequiredMonthly     Money      `json:"required_monthly"`
+	ProjectedCompletion *time.Time `json:"projected_completion"`
+	Completed           bool       `json:"completed"`
+}
+
+// GoalInput — тело запроса на создание цели; без валюты цель копится в валюте карты
+// или в базовой валюте пользователя
+type GoalInput struct {
+	Title       string     `json:"title" binding:"required,notblank,max=255" example:"Vacation"`
+	Description string     `json:"description" binding:"max=1000"`
+	Target      Money      `json:"target" binding:"required,gt=0"`
+	Deadline    *time.Time `json:"deadline" example:"2025-06-01T00:00:00Z"`
+	CardID      *uint      `json:"card_id" binding:"omitempty,gt=0"`
+}
+
+// GoalUpdateInput — тело запроса на изменение цели; валюта и карта цели не меняются
+type GoalUpdateInput struct {
+	Title       string     `json:"title" binding:"required,notblank,max=255" example:"Vacation"`
+	Description string     `json:"description" binding:"max=1000"`
+	Target      Money      `json:"target" binding:"required,gt=0"`
+	Deadline    *time.Time `json:"deadline" example:"2025-06-01T00:00:00Z"`
+}
+
+func (in GoalInput) Goal() Goal {
+	return Goal{
+		Title:       in.Title,
+		Description: in.Description,
+		Target:      in.Target,
+		Deadline:    in.Deadline,
+		CardID:      in.CardID,
+	}
+}
+
+func (in GoalUpdateInput) Goal() Goal {
+	return Goal{Title: in.Title, Description: in.Description, Target: in.Target, Deadline: in.Deadline}
+}
+
+// GoalContributionInput — тело запроса на пополнение цели; без карты деньги не списываются ни с одной карты
+type GoalContributionInput struct {
+	CardID *uint `json:"card_id" binding:"omitempty,gt=0"`
+	Amount Money `json:"amount" binding:"required,gt=0"`
+}
+
+func (in GoalContributionInput) GoalContribution() GoalContribution {
+	return GoalContribution{CardID: in.CardID, Amount: in.Amount}
+}
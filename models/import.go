@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+const (
+	ImportFormatCSV = "csv"
+	ImportFormatOFX = "ofx"
+	ImportFormatQIF = "qif"
+)
+
+const (
+	ImportStatusNew       = "new"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusCreated   = "created"
+	ImportStatusError     = "error"
+)
+
+// CSVMapping описывает, в каких колонках CSV-выписки лежат поля операции.
+// Колонка задаётся названием из заголовка или номером, начиная с 1.
+// Сумма берётся из Amount (со знаком) либо из пары Debit/Credit.
+type CSVMapping struct {
+	Date             string `json:"date" example:"Date"`
+	Amount           string `json:"amount" example:"Amount"`
+	Debit            string `json:"debit"`
+	Credit           string `json:"credit"`
+	Description      string `json:"description" example:"Description"`
+	Category         string `json:"category"`
+	DateFormat       string `json:"date_format" example:"2006-01-02"`
+	Delimiter        string `json:"delimiter" example:","`
+	DecimalSeparator string `json:"decimal_separator" example:"."`
+	HasHeader        bool   `json:"has_header" example:"true"`
+}
+
+// ImportRow — одна операция выписки. Положительная сумма импортируется как доход (income),
+// отрицательная — как расход (expense) по выбранной карте или без карты. Kind — тип Transaction.
+type ImportRow struct {
+	Line        int       `json:"line"`
+	Date        time.Time `json:"date"`
+	Amount      Money     `json:"amount"`
+	Description string    `json:"description"`
+	CategoryID  *uint     `json:"category_id"`
+	Kind        string    `json:"kind"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// ImportReport — результат предпросмотра или импорта выписки
+type ImportReport struct {
+	Format     string      `json:"format"`
+	Total      int         `json:"total"`
+	New        int         `json:"new"`
+	Created    int         `json:"created"`
+	Duplicates int         `json:"duplicates"`
+	Failed     int         `json:"failed"`
+	Rows       []ImportRow `json:"rows"`
+}
@@ -2,13 +2,77 @@ package models
 
 import "time"
 
+// Income — доход в прежнем формате /api/income; хранится как Transaction с типом income
 type Income struct {
-	ID          uint      `json:"id" gorm:"primary_key"`
-	Description string    `json:"description"`
-	Amount      float32   `json:"amount"`
-	User        User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
-	UserID      uint      `json:"-"`
-	CreatedAt   time.Time `json:"-"`
-	UpdatedAt   time.Time `json:"-"`
-	IsDeleted   bool      `json:"-" gorm:"default:false"`
+	ID          uint   `json:"id"`
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"`
+	CardID      *uint  `json:"card_id"`     // Карта, на которую зачисляется доход (необязательно)
+	CategoryID  *uint  `json:"category_id"` // Категория дохода (необязательно)
+	UserID      uint   `json:"-"`
+
+	Date     time.Time `json:"date"`      // Когда получен доход; по умолчанию — момент создания
+	TimeZone string    `json:"time_zone"` // IANA-зона даты (необязательно)
+}
+
+func IncomeFromTransaction(t Transaction) Income {
+	return Income{
+		ID:          t.ID,
+		Description: t.Description,
+		Amount:      t.Amount,
+		CardID:      t.CardID,
+		CategoryID:  t.CategoryID,
+		UserID:      t.UserID,
+		Date:        t.Date,
+		TimeZone:    t.TimeZone,
+	}
+}
+
+func (i Income) Transaction() Transaction {
+	return Transaction{
+		ID:          i.ID,
+		Type:        TransactionTypeIncome,
+		Description: i.Description,
+		Amount:      i.Amount,
+		CardID:      i.CardID,
+		CategoryID:  i.CategoryID,
+		Date:        i.Date,
+		TimeZone:    i.TimeZone,
+		UserID:      i.UserID,
+	}
+}
+
+// IncomeInput — тело запроса на создание дохода
+type IncomeInput struct {
+	Description string    `json:"description" binding:"required,notblank,max=255"`
+	Amount      Money     `json:"amount" binding:"required,gt=0"`
+	CardID      *uint     `json:"card_id" binding:"omitempty,gt=0"`
+	CategoryID  *uint     `json:"category_id" binding:"omitempty,gt=0"`
+	Date        time.Time `json:"date" example:"2024-05-01T12:00:00+05:00"`
+	TimeZone    string    `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+}
+
+// IncomeUpdateInput — тело запроса на изменение дохода; пустые поля оставляют прежние значения
+type IncomeUpdateInput struct {
+	Description string    `json:"description" binding:"omitempty,notblank,max=255"`
+	Amount      Money     `json:"amount" binding:"omitempty,gt=0"`
+	CardID      *uint     `json:"card_id" binding:"omitempty,gt=0"`
+	CategoryID  *uint     `json:"category_id" binding:"omitempty,gt=0"`
+	Date        time.Time `json:"date" example:"2024-05-01T12:00:00+05:00"`
+	TimeZone    string    `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+}
+
+func (in IncomeInput) Income() Income {
+	return Income{
+		Description: in.Description,
+		Amount:      in.Amount,
+		CardID:      in.CardID,
+		CategoryID:  in.CategoryID,
+		Date:        in.Date,
+		TimeZone:    in.TimeZone,
+	}
+}
+
+func (in IncomeUpdateInput) Income() Income {
+	return IncomeInput(in).Income()
 }
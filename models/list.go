@@ -0,0 +1,47 @@
+package models
+
+// Поля, по которым сортируются списки
+const (
+	SortByDate     = "date"
+	SortByAmount   = "amount"
+	SortByCategory = "category"
+)
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// ListParams — номер страницы (с 1), размер страницы и сортировка списка
+type ListParams struct {
+	Page  int
+	Limit int
+	Sort  string
+	Order string
+}
+
+// Offset возвращает число строк, пропускаемых до начала страницы
+func (p ListParams) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// PageInfo — метаданные страницы списка: Total — число строк с учётом фильтров во всех страницах
+type PageInfo struct {
+	Page  int   `json:"page" example:"1"`
+	Limit int   `json:"limit" example:"50"`
+	Total int64 `json:"total" example:"120"`
+	Pages int64 `json:"pages" example:"3"`
+}
+
+func NewPageInfo(params ListParams, total int64) PageInfo {
+	info := PageInfo{Page: params.Page, Limit: params.Limit, Total: total}
+	if params.Limit > 0 {
+		info.Pages = (total + int64(params.Limit) - 1) / int64(params.Limit)
+	}
+	return info
+}
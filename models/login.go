@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Причины неудачного входа в журнале LoginAttempt
+const (
+	LoginFailureInvalidCredentials   = "invalid_credentials"
+	LoginFailureInvalidTwoFactorCode = "invalid_two_factor_code"
+	LoginFailureLocked               = "locked"
+)
+
+// LoginAttempt — запись журнала неудачных попыток входа
+type LoginAttempt struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Username  string    `json:"username" gorm:"size:255;not null;index"`
+	UserID    *uint     `json:"user_id" gorm:"index"` // nil, если пользователь с таким логином не найден
+	IPAddress string    `json:"ip_address" gorm:"size:64;not null;index"`
+	UserAgent string    `json:"user_agent" gorm:"size:512;not null;default:''"`
+	Reason    string    `json:"reason" gorm:"size:32;not null" example:"invalid_credentials"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// LoginThrottle — счётчик неудачных входов подряд по логину или IP-адресу.
+// Key — "user:<логин>" или "ip:<адрес>"; пока не наступил LockedUntil, вход с этим ключом запрещён.
+type LoginThrottle struct {
+	Key           string     `gorm:"primaryKey;size:320"`
+	Failures      int        `gorm:"not null;default:0"`
+	LastFailureAt time.Time  `gorm:"not null;index"`
+	LockedUntil   *time.Time `gorm:"index"`
+}
+
+// LoginClient — откуда пришёл запрос на вход
+type LoginClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// LoginAttemptFilter — условия выборки журнала входов; пустые поля не ограничивают выборку
+type LoginAttemptFilter struct {
+	Username  string
+	IPAddress string
+	From      *time.Time
+	To        *time.Time
+}
@@ -0,0 +1,228 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency — валюта, в которой хранятся суммы без явно указанной валюты
+const DefaultCurrency = "TJS"
+
+// currencyExponents — число знаков после запятой для валют, у которых оно отличается от двух
+var currencyExponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"UZS": 0,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+// MaxCurrencyExponent — наибольшее число знаков после запятой среди известных валют
+const MaxCurrencyExponent = 3
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money — точная денежная сумма в минорных единицах (дирамах, центах, копейках) с кодом валюты ISO 4217.
+// В БД хранится двумя колонками <prefix>minor и <prefix>currency, в JSON — как {"amount": "12.50", "currency": "TJS"}.
+//
+// Сумма без валюты (пришедшая от клиента или из выписки без кода валюты) помнит свою десятичную
+// запись: число знаков после запятой станет известно только вместе с валютой, и сумму пересчитывает
+// InCurrency. До этого Minor хранит её в тысячных долях и годится лишь для проверки знака.
+type Money struct {
+	Minor    int64  `json:"-" gorm:"column:minor;not null;default:0"`
+	Currency string `json:"-" gorm:"column:currency;size:3;not null;default:'TJS'"`
+
+	decimal string // модуль суммы без валюты в исходной десятичной записи
+}
+
+// MoneyDoc описывает JSON-представление Money для Swagger
+type MoneyDoc struct {
+	Amount   string `json:"amount" example:"12.50"`
+	Currency string `json:"currency" example:"TJS"`
+}
+
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: strings.ToUpper(currency)}
+}
+
+// CurrencyExponent возвращает число знаков после запятой для валюты
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ParseMoney разбирает десятичную строку вида "-1234.5" без промежуточного float.
+// Дробная часть длиннее, чем допускает валюта, считается ошибкой, а не округляется.
+// При пустой валюте сумма остаётся без валюты до вызова InCurrency.
+func ParseMoney(amount, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	exp := CurrencyExponent(currency)
+	if currency == "" {
+		exp = MaxCurrencyExponent
+	}
+
+	s := strings.TrimSpace(amount)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" || (hasDot && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+	if len(fracPart) > exp {
+		return Money{}, fmt.Errorf("%w: %q has more than %d fractional digits", ErrInvalidMoney, amount, exp)
+	}
+	var decimal string
+	if currency == "" {
+		decimal = s
+	}
+	fracPart += strings.Repeat("0", exp-len(fracPart))
+
+	minor, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency, decimal: decimal}, nil
+}
+
+// InCurrency возвращает сумму без валюты в валюте currency, заново разбирая её десятичную запись
+// по числу знаков этой валюты. Сумма с валютой возвращается как есть.
+func (m Money) InCurrency(currency string) (Money, error) {
+	if m.Currency != "" {
+		return m, nil
+	}
+	if m.decimal == "" {
+		return NewMoney(m.Minor, currency), nil
+	}
+	parsed, err := ParseMoney(m.decimal, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.Minor < 0 {
+		parsed = parsed.Neg()
+	}
+	return parsed, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal возвращает сумму в виде десятичной строки, например "-12.50"
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	if m.Currency == "" && m.decimal != "" {
+		return sign + m.decimal
+	}
+	digits := strconv.FormatInt(minor, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency, decimal: m.decimal}
+}
+
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(MoneyDoc{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON принимает объект {"amount": "12.50", "currency": "USD"}, а также
+// голое число или строку ("amount": 12.5) от старых клиентов — тогда валюта остаётся пустой,
+// а сервис подставляет её через InCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var doc struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		amount, err := rawDecimal(doc.Amount)
+		if err != nil {
+			return err
+		}
+		parsed, err := ParseMoney(amount, doc.Currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	amount, err := rawDecimal(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(amount, "")
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// rawDecimal достаёт десятичную запись из JSON-числа или JSON-строки, не проходя через float
+func rawDecimal(data json.RawMessage) (string, error) {
+	if len(data) == 0 {
+		return "", ErrInvalidMoney
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
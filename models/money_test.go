@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoneyCurrencyExponent(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		minor    int64
+		decimal  string
+	}{
+		{"1500", "JPY", 1500, "1500"},
+		{"-7", "KRW", -7, "-7"},
+		{"1.5", "KWD", 1500, "1.500"},
+		{"0.001", "BHD", 1, "0.001"},
+		{"12.5", "TJS", 1250, "12.50"},
+	}
+	for _, tt := range tests {
+		money, err := ParseMoney(tt.amount, tt.currency)
+		if err != nil {
+			t.Fatalf("ParseMoney(%q, %q): %v", tt.amount, tt.currency, err)
+		}
+		if money.Minor != tt.minor || money.Decimal() != tt.decimal {
+			t.Errorf("ParseMoney(%q, %q) = %d (%s), want %d (%s)",
+				tt.amount, tt.currency, money.Minor, money.Decimal(), tt.minor, tt.decimal)
+		}
+	}
+
+	for _, tt := range []struct{ amount, currency string }{{"1.5", "JPY"}, {"1.2345", "KWD"}, {"1.234", "USD"}} {
+		if _, err := ParseMoney(tt.amount, tt.currency); !errors.Is(err, ErrInvalidMoney) {
+			t.Errorf("ParseMoney(%q, %q) error = %v, want ErrInvalidMoney", tt.amount, tt.currency, err)
+		}
+	}
+}
+
+func TestMoneyInCurrency(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		minor    int64
+	}{
+		{"1500", "JPY", 1500},
+		{"1500", "USD", 150000},
+		{"1.5", "KWD", 1500},
+		{"1.5", "TJS", 150},
+		{"0.125", "BHD", 125},
+		{"-2.25", "USD", -225},
+	}
+	for _, tt := range tests {
+		pending, err := ParseMoney(tt.amount, "")
+		if err != nil {
+			t.Fatalf("ParseMoney(%q): %v", tt.amount, err)
+		}
+		money, err := pending.InCurrency(tt.currency)
+		if err != nil {
+			t.Fatalf("%q.InCurrency(%q): %v", tt.amount, tt.currency, err)
+		}
+		if money.Minor != tt.minor || money.Currency != tt.currency {
+			t.Errorf("%q.InCurrency(%q) = %s, want %d %s", tt.amount, tt.currency, money, tt.minor, tt.currency)
+		}
+	}
+
+	// Дробная часть, которой у валюты нет, — ошибка, а не округление
+	for _, tt := range []struct{ amount, currency string }{{"1.5", "JPY"}, {"0.125", "USD"}} {
+		pending, err := ParseMoney(tt.amount, "")
+		if err != nil {
+			t.Fatalf("ParseMoney(%q): %v", tt.amount, err)
+		}
+		if _, err = pending.InCurrency(tt.currency); !errors.Is(err, ErrInvalidMoney) {
+			t.Errorf("%q.InCurrency(%q) error = %v, want ErrInvalidMoney", tt.amount, tt.currency, err)
+		}
+	}
+
+	// Знак сохраняется и после Neg
+	pending, _ := ParseMoney("1.5", "")
+	money, err := pending.Neg().InCurrency("KWD")
+	if err != nil || money.Minor != -1500 {
+		t.Errorf("-1.5 in KWD = %v, %v; want -1500", money, err)
+	}
+
+	// Сумма с валютой не пересчитывается
+	explicit := NewMoney(1250, "USD")
+	if money, err = explicit.InCurrency("JPY"); err != nil || money != explicit {
+		t.Errorf("USD amount in JPY = %v, %v; want it unchanged", money, err)
+	}
+}
+
+func TestMoneyUnmarshalWithoutCurrency(t *testing.T) {
+	tests := []struct {
+		json     string
+		currency string
+		minor    int64
+	}{
+		{`1500`, "JPY", 1500},
+		{`"1500"`, "JPY", 1500},
+		{`{"amount": "1500"}`, "JPY", 1500},
+		{`1.5`, "KWD", 1500},
+		{`{"amount": "1.234"}`, "BHD", 1234},
+		{`{"amount": "1.5", "currency": "usd"}`, "JPY", 150},
+	}
+	for _, tt := range tests {
+		var money Money
+		if err := json.Unmarshal([]byte(tt.json), &money); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", tt.json, err)
+		}
+		converted, err := money.InCurrency(tt.currency)
+		if err != nil {
+			t.Fatalf("Unmarshal(%s).InCurrency(%q): %v", tt.json, tt.currency, err)
+		}
+		if converted.Minor != tt.minor {
+			t.Errorf("Unmarshal(%s).InCurrency(%q) = %s, want %d minor units", tt.json, tt.currency, converted, tt.minor)
+		}
+	}
+}
@@ -2,20 +2,63 @@ package models
 
 import "time"
 
+// Outcome — расход без карты в прежнем формате /api/outcome; хранится как Transaction
+// с типом expense без карты
 type Outcome struct {
-	ID          uint            `json:"id" gorm:"primary_key"`
-	Description string          `json:"description"`
-	Category    OutcomeCategory `json:"-" gorm:"foreignKey:CategoryID;references:ID"`
-	CategoryID  uint            `json:"category_id"`
-	Amount      float32         `json:"amount" gorm:"not null"`
-	User        User            `json:"-" gorm:"foreignKey:UserID;references:ID"`
-	UserID      uint            `json:"-"`
-	CreatedAt   time.Time       `json:"-"`
-	UpdatedAt   time.Time       `json:"-"`
-	IsDeleted   bool            `json:"-" gorm:"default:false"`
+	ID          uint   `json:"id"`
+	Description string `json:"description"`
+	CategoryID  uint   `json:"category_id"`
+	Amount      Money  `json:"amount"`
+	UserID      uint   `json:"-"`
+
+	Date     time.Time `json:"date"`      // Когда произошёл расход; по умолчанию — момент создания
+	TimeZone string    `json:"time_zone"` // IANA-зона даты (необязательно)
+}
+
+func OutcomeFromTransaction(t Transaction) Outcome {
+	outcome := Outcome{
+		ID:          t.ID,
+		Description: t.Description,
+		Amount:      t.Amount,
+		UserID:      t.UserID,
+		Date:        t.Date,
+		TimeZone:    t.TimeZone,
+	}
+	if t.CategoryID != nil {
+		outcome.CategoryID = *t.CategoryID
+	}
+	return outcome
+}
+
+func (o Outcome) Transaction() Transaction {
+	categoryID := o.CategoryID
+	return Transaction{
+		ID:          o.ID,
+		Type:        TransactionTypeExpense,
+		Description: o.Description,
+		Amount:      o.Amount,
+		CategoryID:  &categoryID,
+		Date:        o.Date,
+		TimeZone:    o.TimeZone,
+		UserID:      o.UserID,
+	}
+}
+
+// OutcomeInput — тело запроса на создание или замену расхода без карты
+type OutcomeInput struct {
+	Description string    `json:"description" binding:"required,notblank,max=255"`
+	CategoryID  uint      `json:"category_id" binding:"required"`
+	Amount      Money     `json:"amount" binding:"required,gt=0"`
+	Date        time.Time `json:"date" example:"2024-05-01T12:00:00+05:00"`
+	TimeZone    string    `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
 }
 
-type OutcomeCategory struct {
-	ID    uint64 `json:"id" gorm:"primaryKey"`
-	Title string `json:"title" gorm:"not null"`
+func (in OutcomeInput) Outcome() Outcome {
+	return Outcome{
+		Description: in.Description,
+		CategoryID:  in.CategoryID,
+		Amount:      in.Amount,
+		Date:        in.Date,
+		TimeZone:    in.TimeZone,
+	}
 }
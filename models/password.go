@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// PasswordResetToken — одноразовый токен сброса пароля; хранится только SHA-256 самого токена.
+// Токен действует до ExpiresAt и гаснет после первого использования (UsedAt).
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex"`
+	User      User       `gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint       `gorm:"not null;index"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	UsedAt    *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,max=255" example:"user@example.com"`
+}
+
+type ResetPasswordInput struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
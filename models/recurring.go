@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+const (
+	RecurringTypeIncome  = "income"
+	RecurringTypeOutcome = "outcome"
+	RecurringTypeExpense = "expense"
+)
+
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+	FrequencyCron    = "cron"
+)
+
+// RecurringRule — правило регулярной операции (зарплата, аренда, подписка).
+// Фоновый воркер создаёт по нему доходы, расходы или траты на каждую наступившую дату NextRunAt.
+type RecurringRule struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Type        string `json:"type" gorm:"size:16;not null" example:"income"`
+	Description string `json:"description"`
+	Amount      Money  `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	CategoryID  *uint  `json:"category_id"`
+	CardID      *uint  `json:"card_id"` // Карта дохода или траты; у расхода outcome карты нет
+
+	Frequency string `json:"frequency" gorm:"size:16;not null" example:"monthly"`
+	Interval  int    `json:"interval" gorm:"not null;default:1"` // Каждые N дней/недель/месяцев/лет
+	CronExpr  string `json:"cron_expr" example:"0 9 1 * *"`
+
+	StartDate time.Time  `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+	NextRunAt *time.Time `json:"next_run_at" gorm:"index"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	IsActive  bool       `json:"is_active" gorm:"not null"`
+
+	// Failures — сколько раз подряд воркер не смог создать операцию по правилу, RetryAt — когда
+	// он попробует снова, LastError — причина последней неудачи. После нескольких неудач подряд
+	// правило выключается; изменение правила сбрасывает счётчик.
+	Failures  int        `json:"failures" gorm:"not null;default:0"`
+	RetryAt   *time.Time `json:"retry_at"`
+	LastError string     `json:"last_error"`
+
+	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint      `json:"-" gorm:"index"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// RecurringOccurrence фиксирует уже созданную по правилу операцию.
+// Уникальность (rule_id, scheduled_at) делает материализацию идемпотентной.
+type RecurringOccurrence struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	RuleID        uint      `json:"rule_id" gorm:"not null;uniqueIndex:idx_recurring_occurrences_rule_time"`
+	ScheduledAt   time.Time `json:"scheduled_at" gorm:"not null;uniqueIndex:idx_recurring_occurrences_rule_time"`
+	TransactionID uint      `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RecurringPreviewItem — одна будущая (или уже наступившая, но не созданная) операция правила
+type RecurringPreviewItem struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Amount      Money     `json:"amount"`
+	Due         bool      `json:"due"`
+}
+
+// RecurringRuleInput — тело запроса на создание или замену правила; без is_active правило включено
+type RecurringRuleInput struct {
+	Type        string     `json:"type" binding:"required" example:"income"`
+	Description string     `json:"description" binding:"max=255"`
+	Amount      Money      `json:"amount" binding:"required,gt=0"`
+	CategoryID  *uint      `json:"category_id" binding:"omitempty,gt=0"`
+	CardID      *uint      `json:"card_id" binding:"omitempty,gt=0"`
+	Frequency   string     `json:"frequency" binding:"required" example:"monthly"`
+	Interval    int        `json:"interval" binding:"gte=0" example:"1"`
+	CronExpr    string     `json:"cron_expr" binding:"max=128" example:"0 9 1 * *"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	IsActive    *bool      `json:"is_active"`
+}
+
+func (in RecurringRuleInput) RecurringRule() RecurringRule {
+	rule := RecurringRule{
+		Type:        in.Type,
+		Description: in.Description,
+		Amount:      in.Amount,
+		CategoryID:  in.CategoryID,
+		CardID:      in.CardID,
+		Frequency:   in.Frequency,
+		Interval:    in.Interval,
+		CronExpr:    in.CronExpr,
+		StartDate:   in.StartDate,
+		EndDate:     in.EndDate,
+		IsActive:    true,
+	}
+	if in.IsActive != nil {
+		rule.IsActive = *in.IsActive
+	}
+	return rule
+}
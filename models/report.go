@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+const (
+	ReportKindIncome  = "income"
+	ReportKindOutcome = "outcome"
+)
+
+// ReportRow — агрегированная сумма операций одного вида в одной валюте,
+// сгруппированная по периоду, категории или карте
+type ReportRow struct {
+	Period     time.Time
+	Kind       string
+	CategoryID *uint
+	Title      string
+	CardID     *uint
+	CardNumber string
+	Currency   string
+	Minor      int64
+}
+
+type ReportTotals struct {
+	Income  Money `json:"income"`
+	Outcome Money `json:"outcome"`
+	Net     Money `json:"net"`
+}
+
+type ReportPeriod struct {
+	PeriodStart time.Time `json:"period_start"`
+	ReportTotals
+}
+
+type ReportCategory struct {
+	CategoryID uint   `json:"category_id"`
+	Title      string `json:"title"`
+	Outcome    Money  `json:"outcome"`
+}
+
+type ReportCard struct {
+	CardID     uint   `json:"card_id"`
+	CardNumber string `json:"card_number"`
+	ReportTotals
+}
+
+// ReportSummary — доходы, расходы и их разница за период [From, To) в валюте Currency
+type ReportSummary struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	GroupBy    string           `json:"group_by"`
+	Currency   string           `json:"currency"`
+	Totals     ReportTotals     `json:"totals"`
+	Periods    []ReportPeriod   `json:"periods"`
+	ByCategory []ReportCategory `json:"by_category"`
+	ByCard     []ReportCard     `json:"by_card"`
+}
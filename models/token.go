@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// RefreshToken — серверная запись refresh-токена; хранится только SHA-256 самого токена.
+// При обмене токен отзывается и заменяется новым с тем же FamilyID. Повторное предъявление
+// уже обменянного токена означает утечку, и вся цепочка FamilyID отзывается.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex"`
+	FamilyID  string     `gorm:"size:32;not null;index"`
+	User      User       `gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint       `gorm:"not null;index"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// RevokedToken — access-токен, отозванный до истечения срока (выход из сессии)
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;size:32"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// TokenPair — короткоживущий access-токен и refresh-токен для его обновления
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"` // Время жизни access-токена в секундах
+}
+
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refresh_token"`
+}
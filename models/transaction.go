@@ -0,0 +1,126 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	TransactionTypeIncome   = "income"
+	TransactionTypeExpense  = "expense"
+	TransactionTypeTransfer = "transfer"
+)
+
+// Transaction — единая операция пользователя: доход, расход или перевод между картами.
+// Amount всегда положительная, направление задаёт Type. Date — момент самой операции,
+// который задаёт клиент (по умолчанию — момент создания); по нему строятся выборки и отчёты,
+// а в ответах он показывается в зоне TimeZone. Расход без карты — бывший outcome,
+// с картой — бывший expense. Переводы создаются только через /api/transfers, пополнения целей —
+// через /api/goals (как переводы с GoalContributionID), и на баланс через эту запись не влияют.
+type Transaction struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Type        string    `json:"type" gorm:"size:16;not null" example:"expense"`
+	Amount      Money     `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Description string    `json:"description"`
+	Card        Card      `json:"-" gorm:"foreignKey:CardID;references:ID"`
+	CardID      *uint     `json:"card_id" gorm:"index"`
+	Category    Category  `json:"-" gorm:"foreignKey:CategoryID;references:ID"`
+	CategoryID  *uint     `json:"category_id" gorm:"index"`
+	Transfer    Transfer  `json:"-" gorm:"foreignKey:TransferID;references:ID"`
+	TransferID  *uint     `json:"transfer_id" gorm:"index"`
+	Date        time.Time `json:"date" gorm:"not null;index"`
+	TimeZone    string    `json:"time_zone" gorm:"size:64;not null;default:''"` // IANA-зона даты, например Asia/Dushanbe
+	Tags        Tags      `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
+
+	// GoalContributionID — пополнение цели, движение по карте которого отражает запись
+	GoalContribution   GoalContribution `json:"-" gorm:"foreignKey:GoalContributionID;references:ID"`
+	GoalContributionID *uint            `json:"goal_contribution_id" gorm:"index"`
+
+	// LegacyTable и LegacyID указывают, из какой строки incomes, outcomes или expenses
+	// перенесена операция при миграции
+	LegacyTable string `json:"-" gorm:"size:16"`
+	LegacyID    *uint  `json:"-"`
+
+	User      User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint      `json:"-" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	IsDeleted bool      `json:"-" gorm:"not null;default:false"`
+	// DeletedAt — когда операция попала в корзину; по нему считается срок окончательного удаления
+	DeletedAt *time.Time `json:"-"`
+}
+
+// TransactionInput — тело запроса на создание или замену операции. Тип обязателен при создании,
+// при замене он не меняется. Ссылки на карту и категорию проверяет сервис.
+type TransactionInput struct {
+	Type        string    `json:"type" binding:"omitempty,oneof=income expense" example:"expense"`
+	Amount      Money     `json:"amount" binding:"required,gt=0"`
+	Description string    `json:"description" binding:"required,notblank,max=255"`
+	CardID      *uint     `json:"card_id" binding:"omitempty,gt=0"`
+	CategoryID  *uint     `json:"category_id" binding:"omitempty,gt=0"`
+	Date        time.Time `json:"date" example:"2024-05-01T12:00:00+05:00"`
+	TimeZone    string    `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+	Tags        []string  `json:"tags" binding:"max=20,dive,max=64" example:"travel"`
+}
+
+func (in TransactionInput) Transaction() Transaction {
+	return Transaction{
+		Type:        in.Type,
+		Amount:      in.Amount,
+		Description: in.Description,
+		CardID:      in.CardID,
+		CategoryID:  in.CategoryID,
+		Date:        in.Date,
+		TimeZone:    in.TimeZone,
+		Tags:        in.Tags,
+	}
+}
+
+// TransactionFilter — условия выборки операций; пустые поля не ограничивают выборку.
+// From включается в диапазон, To — нет. MinAmount и MaxAmount сравниваются в минорных единицах
+// и, если задана Currency, только с операциями в этой валюте.
+type TransactionFilter struct {
+	Type        string
+	CardIDs     []uint
+	HasCard     *bool
+	CategoryIDs []uint
+	Tag         string
+	Query       string
+	From        *time.Time
+	To          *time.Time
+	MinAmount   *int64
+	MaxAmount   *int64
+	Currency    string
+}
+
+// Tags — метки операции, хранятся в jsonb-массиве
+type Tags []string
+
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *Tags) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = Tags{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported tags value")
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
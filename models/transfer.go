@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+const (
+	TransferStatusCompleted = "completed"
+	TransferStatusCancelled = "cancelled"
+)
+
+// Transfer — перевод между двумя картами пользователя.
+// С карты-источника списывается Amount + Fee, на карту-получатель зачисляется Credited
+// (Amount, пересчитанный в валюту получателя).
+type Transfer struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Description string `json:"description"`
+
+	FromCard   Card `json:"-" gorm:"foreignKey:FromCardID;references:ID"`
+	FromCardID uint `json:"from_card_id" gorm:"not null"`
+
+	ToCard   Card `json:"-" gorm:"foreignKey:ToCardID;references:ID"`
+	ToCardID uint `json:"to_card_id" gorm:"not null"`
+
+	Amount   Money `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Fee      Money `json:"fee" gorm:"embedded;embeddedPrefix:fee_"`
+	Credited Money `json:"credited" gorm:"embedded;embeddedPrefix:credited_"`
+
+	Status string `json:"status" gorm:"size:16;not null;default:'completed'"`
+
+	User   User `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	UserID uint `json:"-" gorm:"index"`
+
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+}
+
+// TransferInput — тело запроса на перевод; сумма и комиссия без валюты считаются в валюте карты-источника
+type TransferInput struct {
+	FromCardID  uint   `json:"from_card_id" binding:"required"`
+	ToCardID    uint   `json:"to_card_id" binding:"required"`
+	Amount      Money  `json:"amount" binding:"required,gt=0"`
+	Fee         Money  `json:"fee" binding:"gte=0"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+func (in TransferInput) Transfer() Transfer {
+	return Transfer{
+		FromCardID:  in.FromCardID,
+		ToCardID:    in.ToCardID,
+		Amount:      in.Amount,
+		Fee:         in.Fee,
+		Description: in.Description,
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Виды записей корзины
+const (
+	TrashEntityTransaction = "transaction"
+	TrashEntityCard        = "card"
+)
+
+// TrashItem — удалённая запись в корзине. Kind — тип операции (income или expense), у карт пуст;
+// Title — описание операции или номер карты, Amount — сумма операции или баланс карты.
+// PurgeAt — когда запись будет удалена окончательно.
+type TrashItem struct {
+	EntityType string    `json:"entity_type" example:"transaction"`
+	EntityID   uint      `json:"entity_id"`
+	Kind       string    `json:"kind" example:"expense"`
+	Title      string    `json:"title"`
+	Amount     Money     `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at" gorm:"-"`
+}
+
+// TrashFilter — условия выборки корзины; пустой EntityType — записи всех видов
+type TrashFilter struct {
+	EntityType string
+}
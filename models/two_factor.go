@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// RecoveryCode — одноразовый код восстановления на случай потери приложения-аутентификатора;
+// хранится только SHA-256 кода
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	User      User   `gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_recovery_codes_user_code"`
+	CodeHash  string `gorm:"size:64;not null;uniqueIndex:idx_recovery_codes_user_code"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TwoFactorChallenge — вход, прошедший проверку пароля и ожидающий второго фактора.
+// Хранится только SHA-256 токена; после MaxTwoFactorAttempts неверных кодов вход отменяется.
+type TwoFactorChallenge struct {
+	ID        uint      `gorm:"primaryKey"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	UserID    uint      `gorm:"not null;index"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+const MaxTwoFactorAttempts = 5
+
+// SignInResult — ответ на вход: пара токенов или, если включена двухфакторная аутентификация,
+// токен второго шага для /auth/sign-in/2fa
+type SignInResult struct {
+	*TokenPair
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	// TwoFactorSetupRequired — пользователь обязан подключить двухфакторную аутентификацию;
+	// до этого доступны только /api/me/2fa
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+}
+
+type TwoFactorSignInInput struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=32" example:"123456"` // Код TOTP или код восстановления
+}
+
+// TwoFactorSetup — секрет TOTP для подключения приложения-аутентификатора
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/CoinKeeper:user?secret=..."` // Содержимое QR-кода
+}
+
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required,max=32" example:"123456"`
+}
+
+type DisableTwoFactorInput struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,max=32" example:"123456"` // Код TOTP или код восстановления
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorRequirementInput struct {
+	Required bool `json:"required"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool  `json:"enabled"`
+	Required          bool  `json:"required"` // По настройкам сервиса или решению администратора
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
@@ -2,22 +2,114 @@ package models
 
 import "time"
 
+// Роли пользователей: admin управляет пользователями через /api/admin
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	FullName  string    `json:"full_name"`
-	Username  string    `json:"username" gorm:"unique"`
-	Password  string    `json:"password" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	FullName     string    `json:"full_name"`
+	Username     string    `json:"username" gorm:"unique"`
+	Email        string    `json:"email" gorm:"size:255;not null;default:'';uniqueIndex:idx_users_email,where:email <> ''"` // Адрес для восстановления пароля, необязателен
+	Password     string    `json:"password,omitempty" gorm:"not null"`
+	BaseCurrency string    `json:"base_currency" gorm:"size:3;not null;default:'TJS'"` // Валюта пересчёта балансов и отчётов
+	Role         string    `json:"role" gorm:"size:16;not null;default:'user'"`
+	Locale       string    `json:"locale" gorm:"size:35;not null;default:''"`    // Язык интерфейса, тег BCP 47
+	TimeZone     string    `json:"time_zone" gorm:"size:64;not null;default:''"` // IANA-зона дат по умолчанию, пустая — зона сервера
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// IsDeleted — учётная запись удалена и ждёт окончательного удаления после DeletedAt + срок хранения
+	IsDeleted bool       `json:"-" gorm:"not null;default:false"`
+	DeletedAt *time.Time `json:"-" gorm:"index"`
+
+	// TokensRevokedAt — момент выхода из всех сессий: access-токены, выданные раньше, не принимаются
+	TokensRevokedAt *time.Time `json:"-"`
+
+	// TwoFactorEnabled — при входе кроме пароля нужен код TOTP или код восстановления
+	TwoFactorEnabled bool `json:"two_factor_enabled" gorm:"not null;default:false"`
+	// TwoFactorRequired — администратор обязал пользователя подключить двухфакторную аутентификацию
+	TwoFactorRequired bool `json:"two_factor_required" gorm:"not null;default:false"`
+	// TOTPSecret — секрет TOTP в base32; до подтверждения кодом двухфакторная аутентификация не включена
+	TOTPSecret string `json:"-" gorm:"size:64;not null;default:''"`
+	// TOTPLastStep — шаг последнего принятого кода TOTP, чтобы один код нельзя было использовать дважды
+	TOTPLastStep int64 `json:"-" gorm:"not null;default:0"`
+}
+
+// SignUpInput — тело запроса на регистрацию
+type SignUpInput struct {
+	FullName     string `json:"full_name" binding:"max=255"`
+	Username     string `json:"username" binding:"required,notblank,max=64"`
+	Email        string `json:"email" binding:"max=255" example:"user@example.com"`
+	Password     string `json:"password" binding:"required,max=128"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3" example:"TJS"`
+	Locale       string `json:"locale" binding:"max=35" example:"ru-RU"`
+	TimeZone     string `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+}
+
+func (in SignUpInput) User() User {
+	return User{
+		FullName:     in.FullName,
+		Username:     in.Username,
+		Email:        in.Email,
+		Password:     in.Password,
+		BaseCurrency: in.BaseCurrency,
+		Locale:       in.Locale,
+		TimeZone:     in.TimeZone,
+	}
+}
+
+// AdminUserInput — пользователь, которого создаёт администратор; без роли создаётся обычный пользователь
+type AdminUserInput struct {
+	FullName     string `json:"full_name" binding:"max=255"`
+	Username     string `json:"username" binding:"required,notblank,max=64"`
+	Email        string `json:"email" binding:"max=255" example:"user@example.com"`
+	Password     string `json:"password" binding:"required,max=128"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3" example:"TJS"`
+	Role         string `json:"role" binding:"omitempty,oneof=user admin" example:"user"`
+}
+
+func (in AdminUserInput) User() User {
+	return User{
+		FullName:     in.FullName,
+		Username:     in.Username,
+		Email:        in.Email,
+		Password:     in.Password,
+		BaseCurrency: in.BaseCurrency,
+		Role:         in.Role,
+	}
+}
+
+// AdminUserUpdateInput — изменение пользователя администратором; пустые поля не меняются
+type AdminUserUpdateInput struct {
+	FullName     string `json:"full_name" binding:"max=255"`
+	Email        string `json:"email" binding:"max=255" example:"user@example.com"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3" example:"TJS"`
+	Role         string `json:"role" binding:"omitempty,oneof=user admin" example:"user"`
 }
 
-type SwagUser struct {
-	FullName string `json:"full_name"`
-	Username string `json:"username" gorm:"unique"`
-	Password string `json:"password" gorm:"not null"`
+func (in AdminUserUpdateInput) User() User {
+	return User{FullName: in.FullName, Email: in.Email, BaseCurrency: in.BaseCurrency, Role: in.Role}
 }
 
 type SignInInput struct {
-	Username string `json:"username" gorm:"unique"`
-	Password string `json:"password" gorm:"not null"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ProfileInput — изменение профиля текущего пользователя; пустые поля не меняются
+type ProfileInput struct {
+	FullName     string `json:"full_name" binding:"max=255"`
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3" example:"TJS"`
+	Locale       string `json:"locale" binding:"max=35" example:"ru-RU"`
+	TimeZone     string `json:"time_zone" binding:"max=64" example:"Asia/Dushanbe"`
+}
+
+// DeleteAccountInput — подтверждение удаления учётной записи; Code нужен при включённой
+// двухфакторной аутентификации
+type DeleteAccountInput struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"max=32" example:"123456"`
 }
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// auditMeta описывает для журнала изменений, кто и откуда выполняет запрос
+func auditMeta(c *gin.Context) models.AuditMeta {
+	return models.AuditMeta{
+		ActorID:   c.GetUint(userIDCtx),
+		IPAddress: c.ClientIP(),
+		RequestID: c.GetString(requestIDCtx),
+	}
+}
+
+// GetAuditEntries
+// @Summary Get Audit Log
+// @Security ApiKeyAuth
+// @Tags audit
+// @Description get a page of the history of changes to own incomes, outcomes, expenses, cards, transfers and categories, newest first by default
+// @ID get-audit-entries
+// @Produce json
+// @Param entity_type query string false "transaction, card, transfer or category"
+// @Param entity_id query integer false "only changes of the record with the id"
+// @Param action query string false "create, update, delete or restore"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, profile time zone by default"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} auditList
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/audit [get]
+func GetAuditEntries(c *gin.Context) {
+	filter := models.AuditFilter{
+		EntityType: c.Query("entity_type"),
+		Action:     c.Query("action"),
+	}
+	if value := c.Query("entity_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			handleError(c, errs.ErrValidationFailed)
+			return
+		}
+		entityID := uint(id)
+		filter.EntityID = &entityID
+	}
+	var err error
+	if filter.From, filter.To, err = queryDateRange(c); err != nil {
+		handleError(c, err)
+		return
+	}
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	entries, page, err := service.GetAuditEntries(c.GetUint(userIDCtx), filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, auditList{AuditEntries: entries, Pagination: page})
+}
@@ -1,9 +1,12 @@
 package controllers
 
 import (
+	"coinkeeper/errs"
 	"coinkeeper/models"
 	"coinkeeper/pkg/service"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 )
 
@@ -14,18 +17,24 @@ import (
 // @ID create-account
 // @Accept json
 // @Produce json
-// @Param input body models.SwagUser true "account info"
+// @Param input body models.SignUpInput true "account info"
 // @Success 200 {object} defaultResponse
 // @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /auth/sign-up [post]
 func SignUp(c *gin.Context) {
-	var user models.User
-	if err := c.BindJSON(&user); err != nil {
+	var input models.SignUpInput
+	if err := bindJSON(c, &input); err != nil {
 		handleError(c, err)
 		return
 	}
+	user := input.User()
+	// Роль и обязательность двухфакторной аутентификации при регистрации не выбираются,
+	// их назначает администратор
+	user.Role = models.RoleUser
+	user.TwoFactorRequired = false
 	err := service.CreateUser(user)
 	if err != nil {
 		handleError(c, err)
@@ -37,27 +46,145 @@ func SignUp(c *gin.Context) {
 // SignIn
 // @Summary SignIn
 // @Tags auth
-// @Description sign in to account
+// @Description sign in to account; with two-factor authentication enabled a challenge token for /auth/sign-in/2fa is returned instead of tokens
 // @ID sign-in-to-account
 // @Accept json
 // @Produce json
 // @Param input body models.SignInInput true "sign-in info"
-// @Success 200 {object} accessTokenResponse
+// @Success 200 {object} models.SignInResult
 // @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 429 {object} ErrorResponse "too many failed attempts, retry after the Retry-After header seconds"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /auth/sign-in [post]
 func SignIn(c *gin.Context) {
-	var user models.User
-	if err := c.BindJSON(&user); err != nil {
+	var input models.SignInInput
+	if err := bindJSON(c, &input); err != nil {
 		handleError(c, err)
 		return
 	}
-	accessToken, err := service.SignIn(user.Username, user.Password)
+	result, err := service.SignIn(input.Username, input.Password, loginClient(c))
 	if err != nil {
 		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, accessTokenResponse{accessToken})
+	c.JSON(http.StatusOK, result)
 
 }
+
+// SignInTwoFactor
+// @Summary SignIn Second Step
+// @Tags auth
+// @Description finish sign-in with a TOTP code or a recovery code; the challenge token expires after a few minutes or five wrong codes
+// @ID sign-in-two-factor
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorSignInInput true "challenge token from sign-in and a code"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 429 {object} ErrorResponse "too many failed attempts, retry after the Retry-After header seconds"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/sign-in/2fa [post]
+func SignInTwoFactor(c *gin.Context) {
+	var input models.TwoFactorSignInInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	tokens, err := service.SignInWithTwoFactor(input.ChallengeToken, input.Code, loginClient(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh
+// @Summary Refresh
+// @Tags auth
+// @Description exchange a refresh token for a new access and refresh token pair; the old refresh token stops working
+// @ID refresh-tokens
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshTokenInput true "refresh token"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/refresh [post]
+func Refresh(c *gin.Context) {
+	var input models.RefreshTokenInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	if input.RefreshToken == "" {
+		handleError(c, errs.NewFieldError("refresh_token", errs.FieldRequired, "is required"))
+		return
+	}
+	tokens, err := service.RefreshTokens(input.RefreshToken)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout
+// @Summary Logout
+// @Security ApiKeyAuth
+// @Tags auth
+// @Description end the current session: revoke the access token and the refresh token of the session
+// @ID logout
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshTokenInput false "refresh token of the session"
+// @Success 200 {object} defaultResponse
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/logout [post]
+func Logout(c *gin.Context) {
+	// Тело необязательно: без refresh-токена отзывается только access-токен
+	var input models.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	claims := c.MustGet(tokenClaimsCtx).(*service.CustomClaims)
+	if err := service.Logout(claims, input.RefreshToken); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("logged out successfully"))
+}
+
+// LogoutAll
+// @Summary Logout All
+// @Security ApiKeyAuth
+// @Tags auth
+// @Description end all sessions of the user on all devices
+// @ID logout-all
+// @Produce json
+// @Success 200 {object} defaultResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/logout-all [post]
+func LogoutAll(c *gin.Context) {
+	if err := service.LogoutAll(c.GetUint(userIDCtx)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("all sessions logged out successfully"))
+}
+
+// loginClient — IP-адрес и User-Agent клиента для учёта неудачных входов
+func loginClient(c *gin.Context) models.LoginClient {
+	return models.LoginClient{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GetAllBudgets
+// @Summary Get All Budgets
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description get list of all budgets, optionally for one month
+// @ID get-all-budgets
+// @Produce json
+// @Param year query integer false "year of the budgets"
+// @Param month query integer false "month of the budgets (1-12)"
+// @Success 200 {array} models.Budget
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets [get]
+func GetAllBudgets(c *gin.Context) {
+	year, month, err := parseBudgetPeriod(c, false)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	budgets, err := service.GetAllBudgets(userID, year, month)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"budgets": budgets})
+}
+
+// GetBudgetsStatus
+// @Summary Get Budgets Status
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description get spent vs. limit of all budgets for a month (current month by default)
+// @ID get-budgets-status
+// @Produce json
+// @Param year query integer false "year of the budgets"
+// @Param month query integer false "month of the budgets (1-12)"
+// @Success 200 {array} models.BudgetStatus
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets/status [get]
+func GetBudgetsStatus(c *gin.Context) {
+	year, month, err := parseBudgetPeriod(c, true)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	statuses, err := service.GetBudgetsStatus(userID, year, month)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"budgets": statuses})
+}
+
+// GetBudgetByID
+// @Summary Get Budget By ID
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description get budget by ID
+// @ID get-budget-by-id
+// @Produce json
+// @Param id path integer true "id of the budget"
+// @Success 200 {object} models.Budget
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets/{id} [get]
+func GetBudgetByID(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	budget, err := service.GetBudgetByID(userID, uint(budgetID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, budget)
+}
+
+// GetBudgetStatus
+// @Summary Get Budget Status
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description get spent, remaining amount and percentage of the budget
+// @ID get-budget-status
+// @Produce json
+// @Param id path integer true "id of the budget"
+// @Success 200 {object} models.BudgetStatus
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets/{id}/status [get]
+func GetBudgetStatus(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	status, err := service.GetBudgetStatus(userID, uint(budgetID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// CreateBudget
+// @Summary Create Budget
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description set a monthly spending limit for an outcome category
+// @ID create-budget
+// @Accept json
+// @Produce json
+// @Param input body models.BudgetInput true "new budget info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets [post]
+func CreateBudget(c *gin.Context) {
+	var input models.BudgetInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	budget := input.Budget()
+	budget.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateBudget(budget); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("budget created successfully"))
+}
+
+// UpdateBudget
+// @Summary Update Budget
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description update existed budget
+// @ID update-budget
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the budget"
+// @Param input body models.BudgetInput true "budget update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets/{id} [put]
+func UpdateBudget(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.BudgetInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	budget := input.Budget()
+	budget.ID = uint(budgetID)
+	budget.UserID = c.GetUint(userIDCtx)
+	if err = service.UpdateBudget(budget); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("budget updated successfully"))
+}
+
+// DeleteBudget
+// @Summary Delete Budget By ID
+// @Security ApiKeyAuth
+// @Tags budgets
+// @Description delete budget by ID
+// @ID delete-budget-by-id
+// @Param id path integer true "id of the budget"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/budgets/{id} [delete]
+func DeleteBudget(c *gin.Context) {
+	budgetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteBudget(uint(budgetID), userID); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("budget deleted successfully"))
+}
+
+// parseBudgetPeriod читает year и month из query. Если defaultToCurrent, пустые значения
+// заменяются текущим месяцем, иначе остаются нулями (без фильтра).
+func parseBudgetPeriod(c *gin.Context, defaultToCurrent bool) (year, month int, err error) {
+	if defaultToCurrent {
+		now := time.Now()
+		year, month = now.Year(), int(now.Month())
+	}
+
+	if value := c.Query("year"); value != "" {
+		if year, err = strconv.Atoi(value); err != nil {
+			return 0, 0, errs.ErrValidationFailed
+		}
+	}
+	if value := c.Query("month"); value != "" {
+		if month, err = strconv.Atoi(value); err != nil || month < 1 || month > 12 {
+			return 0, 0, errs.ErrValidationFailed
+		}
+	}
+	return year, month, nil
+}
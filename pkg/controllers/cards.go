@@ -13,27 +13,44 @@ import (
 // @Summary Get All Cards
 // @Security ApiKeyAuth
 // @Tags cards
-// @Description get list of all card
+// @Description get a page of cards of the user, newest first by default
 // @ID get-all-cards
 // @Produce json
-// @Param q query string false "fill if you need search"
-// @Success 200 {array} models.Card
+// @Param id query string false "card IDs separated by commas"
+// @Param from query string false "first day of the creation date range (YYYY-MM-DD)"
+// @Param to query string false "last day of the creation date range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, e.g. Asia/Dushanbe, profile time zone by default"
+// @Param min_amount query string false "minimal balance, e.g. 10.50"
+// @Param max_amount query string false "maximal balance, e.g. 100"
+// @Param currency query string false "only cards in the currency"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param sort query string false "date or amount, date by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} cardList
 // @Failure 400 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
-// @Router /api/card [get]
+// @Router /api/cards [get]
 func GetAllCards(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	filter, err := bindCardFilter(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
-	cards, err := service.GetAllCards(userID)
+
+	userID := c.GetUint(userIDCtx)
+	cards, page, err := service.GetAllCards(userID, filter, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"cards": cards})
+	c.JSON(http.StatusOK, cardList{Cards: cards, Pagination: page})
 }
 
 // GetCardByID
@@ -45,25 +62,49 @@ func GetAllCards(c *gin.Context) {
 // @Produce json
 // @Param id path integer true "id of the card"
 // @Success 200 {object} models.Card
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/cards/{id} [get]
 func GetCardByID(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
-	cardID, err := strconv.Atoi(c.Param("cardID"))
+	cardID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
+
+	userID := c.GetUint(userIDCtx)
 	card, err := service.GetCardByID(userID, uint(cardID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, card)
 }
 
+// GetCardsBalance
+// @Summary Get Cards Balance
+// @Security ApiKeyAuth
+// @Tags cards
+// @Description get balances of all cards converted to one currency
+// @ID get-cards-balance
+// @Produce json
+// @Param currency query string false "target currency, base currency of the user by default"
+// @Success 200 {object} models.CardsBalance
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/cards/balance [get]
+func GetCardsBalance(c *gin.Context) {
+	userID := c.GetUint(userIDCtx)
+	balance, err := service.GetCardsBalance(userID, c.Query("currency"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, balance)
+}
+
 // CreateCard
 // @Summary Create Card
 // @Security ApiKeyAuth
@@ -72,19 +113,20 @@ func GetCardByID(c *gin.Context) {
 // @ID create-new-card
 // @Accept json
 // @Produce json
-// @Param input body models.Card true "new card info"
+// @Param input body models.CardInput true "new card info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/cards [post]
 func CreateCard(c *gin.Context) {
-	var card models.Card
-
-	if err := c.BindJSON(&card); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.CardInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	card := input.Card()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -94,7 +136,7 @@ func CreateCard(c *gin.Context) {
 
 	card.UserID = userID // Устанавливаем ID пользователя
 
-	if err := service.CreateCard(card); err != nil {
+	if err := service.CreateCard(card, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -105,30 +147,37 @@ func CreateCard(c *gin.Context) {
 // @Summary Update Card Balance
 // @Security ApiKeyAuth
 // @Tags cards
-// @Description Update the balance of an existing card by its ID
+// @Description change the balance of an own card by the amount, negative amount withdraws; amount without currency is in the card currency
 // @ID update-card-balance
 // @Accept json
 // @Produce json
-// @Param id path integer true "ID of the card"
-// @Param input body struct { Balance float32 } true "New card balance"
+// @Param id path integer true "id of the card"
+// @Param input body models.CardBalanceInput true "amount to add to the balance"
 // @Success 200 {object} defaultResponse
-// @Failure 400 {object} ErrorResponse "Invalid input"
-// @Failure 404 {object} ErrorResponse "Card not found"
-// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
-
+// @Router /api/cards/{id} [put]
 func UpdateCardBalance(c *gin.Context) {
-	var updateRequest struct {
-		CardID uint    `json:"card_id"`
-		Amount float32 `json:"amount"` // Сумма для пополнения
+	cardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
 	}
 
-	if err := c.BindJSON(&updateRequest); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var updateRequest models.CardBalanceInput
+	if err = bindJSON(c, &updateRequest); err != nil {
+		handleError(c, err)
+		return
+	}
+	if updateRequest.CardID != 0 && updateRequest.CardID != uint(cardID) {
+		handleError(c, errs.NewFieldError("card_id", errs.FieldInvalid, "must match the card in the path"))
 		return
 	}
 
-	if err := service.UpdateCardBalance(updateRequest.CardID, updateRequest.Amount); err != nil {
+	userID := c.GetUint(userIDCtx)
+	if err = service.UpdateCardBalance(userID, uint(cardID), updateRequest.Amount, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -144,7 +193,7 @@ func UpdateCardBalance(c *gin.Context) {
 // @ID delete-card-by-id
 // @Param id path integer true "id of the card"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/cards/{id} [delete]
@@ -161,10 +210,37 @@ func DeleteCard(c *gin.Context) {
 		return
 	}
 
-	if err := service.DeleteCard(uint(cardID), userID); err != nil {
+	if err := service.DeleteCard(uint(cardID), userID, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, defaultResponse{Message: "Card deleted successfully"})
 }
+
+// RestoreCard
+// @Summary Restore Card By ID
+// @Security ApiKeyAuth
+// @Tags cards
+// @Description restore deleted card from the trash with the balance it had when deleted
+// @ID restore-card-by-id
+// @Param id path integer true "id of the card"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/cards/{id}/restore [post]
+func RestoreCard(c *gin.Context) {
+	cardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.RestoreCard(uint(cardID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("card restored successfully"))
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllCategories
+// @Summary Get All Categories
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description get own and shared categories, flat or as a tree
+// @ID get-all-categories
+// @Produce json
+// @Param type query string false "income or outcome, both by default"
+// @Param tree query boolean false "nest child categories into their parents"
+// @Success 200 {array} models.Category
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories [get]
+func GetAllCategories(c *gin.Context) {
+	tree, err := strconv.ParseBool(c.DefaultQuery("tree", "false"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	categories, err := service.GetAllCategories(userID, c.Query("type"), tree)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// GetCategoryByID
+// @Summary Get Category By ID
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description get category by ID
+// @ID get-category-by-id
+// @Produce json
+// @Param id path integer true "id of the category"
+// @Success 200 {object} models.Category
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories/{id} [get]
+func GetCategoryByID(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	category, err := service.GetCategoryByID(userID, uint(categoryID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// CreateCategory
+// @Summary Create Category
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description create new income or outcome category, optionally nested into a parent of the same type
+// @ID create-category
+// @Accept json
+// @Produce json
+// @Param input body models.CategoryInput true "new category info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories [post]
+func CreateCategory(c *gin.Context) {
+	var input models.CategoryInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	category := input.Category()
+	category.UserID = &userID
+	if err := service.CreateCategory(category); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("category created successfully"))
+}
+
+// UpdateCategory
+// @Summary Update Category
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description update title, parent, icon and color of an own category; the type cannot be changed
+// @ID update-category
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the category"
+// @Param input body models.CategoryInput true "category update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories/{id} [put]
+func UpdateCategory(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.CategoryInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	category := input.Category()
+	category.ID = uint(categoryID)
+	category.UserID = &userID
+	if err = service.UpdateCategory(category); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("category updated successfully"))
+}
+
+// DeleteCategory
+// @Summary Delete Category By ID
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description delete an own category that is not used by any transaction, budget or recurring rule; child categories move to its parent
+// @ID delete-category-by-id
+// @Param id path integer true "id of the category"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories/{id} [delete]
+func DeleteCategory(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteCategory(uint(categoryID), userID); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("category deleted successfully"))
+}
+
+// MergeCategory
+// @Summary Merge Category
+// @Security ApiKeyAuth
+// @Tags categories
+// @Description move transactions, budgets, recurring rules and child categories of an own category into the target category of the same type and delete it
+// @ID merge-category
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the category to merge"
+// @Param input body models.CategoryMerge true "target category"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/categories/{id}/merge [post]
+func MergeCategory(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var merge models.CategoryMerge
+	if err = bindJSON(c, &merge); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.MergeCategory(userID, uint(categoryID), merge.TargetID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("category merged successfully"))
+}
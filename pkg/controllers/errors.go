@@ -2,13 +2,19 @@ package controllers
 
 import (
 	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
+	"strconv"
 )
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+	// Fields — ошибки отдельных полей запроса, если их удалось определить
+	Fields []errs.FieldError `json:"fields,omitempty"`
 }
 
 func newErrorResponse(message string) ErrorResponse {
@@ -17,14 +23,54 @@ func newErrorResponse(message string) ErrorResponse {
 	}
 }
 
+// handleError отвечает статусом, соответствующим ошибке. Неразобранный запрос — 400,
+// разобранный, но с недопустимыми значениями полей — 422; в обоих случаях ответ
+// перечисляет ошибки полей, если они известны.
 func handleError(c *gin.Context, err error) {
-	if errors.Is(err, errs.ErrUsernameUniquenessFailed) ||
-		errors.Is(err, errs.ErrIncorrectUsernameOrPassword) {
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		status := http.StatusBadRequest
+		if errors.Is(err, errs.ErrInvalidFields) {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, ErrorResponse{Error: validationErr.Err.Error(), Fields: validationErr.Fields})
+	} else if errors.Is(err, errs.ErrInvalidFields) {
+		c.JSON(http.StatusUnprocessableEntity, newErrorResponse(err.Error()))
+	} else if errors.Is(err, models.ErrInvalidMoney) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error(), Fields: []errs.FieldError{{
+			Field: "amount", Code: errs.FieldInvalid, Message: "must be a decimal amount",
+		}}})
+	} else if errors.Is(err, errs.ErrUsernameUniquenessFailed) ||
+		errors.Is(err, errs.ErrIncorrectUsernameOrPassword) ||
+		errors.Is(err, errs.ErrCurrencyMismatch) ||
+		errors.Is(err, errs.ErrValidationFailed) ||
+		errors.Is(err, errs.ErrExchangeRateNotFound) ||
+		errors.Is(err, errs.ErrExchangeRateAlreadyExists) ||
+		errors.Is(err, errs.ErrTransferAlreadyCancelled) ||
+		errors.Is(err, errs.ErrBudgetAlreadyExists) ||
+		errors.Is(err, errs.ErrInvalidStatement) ||
+		errors.Is(err, errs.ErrCategoryInUse) ||
+		errors.Is(err, errs.ErrEmailUniquenessFailed) ||
+		errors.Is(err, errs.ErrIncorrectPassword) ||
+		errors.Is(err, errs.ErrInvalidResetToken) ||
+		errors.Is(err, errs.ErrInvalidTwoFactorCode) ||
+		errors.Is(err, errs.ErrTwoFactorAlreadyEnabled) ||
+		errors.Is(err, errs.ErrTwoFactorNotEnabled) ||
+		errors.Is(err, errs.ErrCardDeleted) {
 		c.JSON(http.StatusBadRequest, newErrorResponse(err.Error()))
 	} else if errors.Is(err, errs.ErrRecordNotFound) ||
 		errors.Is(err, errs.ErrOperationNotFound) {
 		c.JSON(http.StatusNotFound, newErrorResponse(err.Error()))
-	} else if errors.Is(err, errs.ErrPermissionDenied) {
+	} else if errors.Is(err, errs.ErrInvalidToken) {
+		c.JSON(http.StatusUnauthorized, newErrorResponse(err.Error()))
+	} else if errors.Is(err, errs.ErrTooManyLoginAttempts) {
+		var locked *service.LoginLockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		}
+		c.JSON(http.StatusTooManyRequests, newErrorResponse(err.Error()))
+	} else if errors.Is(err, errs.ErrPermissionDenied) ||
+		errors.Is(err, errs.ErrTwoFactorRequired) {
 		c.JSON(http.StatusForbidden, newErrorResponse(err.Error()))
 	} else {
 		c.JSON(http.StatusInternalServerError, newErrorResponse(errs.ErrSomethingWentWrong.Error()))
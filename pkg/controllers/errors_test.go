@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"another user's record", errs.ErrPermissionDenied, http.StatusForbidden},
+		{"missing operation", errs.ErrOperationNotFound, http.StatusNotFound},
+		{"missing record", errs.ErrRecordNotFound, http.StatusNotFound},
+		{"wrapped permission error", fmt.Errorf("card 7: %w", errs.ErrPermissionDenied), http.StatusForbidden},
+		{"invalid field", errs.NewFieldError("limit", errs.FieldTooSmall, "must be greater than 0"), http.StatusUnprocessableEntity},
+		{"currency mismatch of a field", errs.WithField(errs.ErrCurrencyMismatch, "fee", errs.FieldInvalid, "must be in the card currency TJS"), http.StatusBadRequest},
+		{"card in the trash", errs.WithField(errs.ErrCardDeleted, "card_id", errs.FieldInvalid, "card is in the trash"), http.StatusBadRequest},
+		{"invalid money", fmt.Errorf("%w: %q", models.ErrInvalidMoney, "1.2.3"), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			handleError(c, tt.err)
+			if recorder.Code != tt.status {
+				t.Errorf("handleError(%v) status = %d, want %d", tt.err, recorder.Code, tt.status)
+			}
+		})
+	}
+}
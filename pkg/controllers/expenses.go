@@ -16,25 +16,43 @@ import (
 // @Description get list of all expense
 // @ID get-all-expenses
 // @Produce json
-// @Param q query string false "fill if you need search"
-// @Success 200 {array} models.Expense
+// @Param q query string false "search in description"
+// @Param card_id query string false "card IDs separated by commas"
+// @Param category_id query string false "category IDs separated by commas"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, e.g. Asia/Dushanbe, profile time zone by default"
+// @Param min_amount query string false "minimal amount, e.g. 10.50"
+// @Param max_amount query string false "maximal amount, e.g. 100"
+// @Param currency query string false "only transactions in the currency"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param sort query string false "date, amount or category, date by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} expenseList
 // @Failure 400 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/expense [get]
 func GetAllExpenses(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	filter, err := bindTransactionFilter(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
 
-	expenses, err := service.GetAllExpenses(userID)
+	userID := c.GetUint(userIDCtx)
+	expenses, page, err := service.GetAllExpenses(userID, filter, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"expenses": expenses})
+	c.JSON(http.StatusOK, expenseList{Expenses: expenses, Pagination: page})
 }
 
 // GetExpenseByID
@@ -46,21 +64,21 @@ func GetAllExpenses(c *gin.Context) {
 // @Produce json
 // @Param id path integer true "id of the expense"
 // @Success 200 {object} models.Expense
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/expenses/{id} [get]
 func GetExpenseByID(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
-	expenseID, err := strconv.Atoi(c.Param("expenseID"))
+	expenseID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid expense ID"})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
 
+	userID := c.GetUint(userIDCtx)
 	expense, err := service.GetExpenseByID(userID, uint(expenseID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, expense)
@@ -74,18 +92,20 @@ func GetExpenseByID(c *gin.Context) {
 // @ID create-new-expense
 // @Accept json
 // @Produce json
-// @Param input body models.Expense true "new expense info"
+// @Param input body models.ExpenseInput true "new expense info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/expenses [post]
 func CreateExpense(c *gin.Context) {
-	var expense models.Expense
-	if err := c.BindJSON(&expense); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.ExpenseInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	expense := input.Expense()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -93,7 +113,7 @@ func CreateExpense(c *gin.Context) {
 		return
 	}
 	expense.UserID = userID
-	if err := service.CreateExpense(expense); err != nil {
+	if err := service.CreateExpense(expense, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -109,9 +129,10 @@ func CreateExpense(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path integer true "id of the expense"
-// @Param input body models.Expense true "expense update info"
+// @Param input body models.ExpenseInput true "expense update info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/expenses/{id} [put]
@@ -122,11 +143,12 @@ func UpdateExpense(c *gin.Context) {
 		return
 	}
 
-	var expense models.Expense
-	if err = c.BindJSON(&expense); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.ExpenseInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	expense := input.Expense()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -135,7 +157,7 @@ func UpdateExpense(c *gin.Context) {
 	}
 	expense.ID = uint(expenseID)
 	expense.UserID = userID
-	if err = service.UpdateExpense(expense); err != nil {
+	if err = service.UpdateExpense(expense, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -150,7 +172,7 @@ func UpdateExpense(c *gin.Context) {
 // @ID delete-expense-by-id
 // @Param id path integer true "id of the expense"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/expenses/{id} [delete]
@@ -166,9 +188,36 @@ func DeleteExpense(c *gin.Context) {
 		return
 	}
 
-	if err = service.DeleteExpense(uint(expenseID), userID); err != nil {
+	if err = service.DeleteExpense(uint(expenseID), userID, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "expense deleted successfully"})
 }
+
+// RestoreExpense
+// @Summary Restore Expense By ID
+// @Security ApiKeyAuth
+// @Tags expenses
+// @Description restore deleted expense from the trash; the card must not be in the trash
+// @ID restore-expense-by-id
+// @Param id path integer true "id of the expense"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/expenses/{id}/restore [post]
+func RestoreExpense(c *gin.Context) {
+	expenseID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.RestoreExpense(uint(expenseID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("expense restored successfully"))
+}
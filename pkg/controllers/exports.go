@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/exporter"
+	"coinkeeper/pkg/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// Export
+// @Summary Export Data
+// @Security ApiKeyAuth
+// @Tags export
+// @Description stream transactions, cards and categories as CSV (zip of CSV files for several datasets), JSON or XLSX
+// @ID export-data
+// @Produce json
+// @Produce text/csv
+// @Produce application/zip
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv, json or xlsx, json by default"
+// @Param datasets query string false "comma separated list of transactions, cards and categories, all by default"
+// @Param from query string false "first day of the range (YYYY-MM-DD), no limit by default"
+// @Param to query string false "last day of the range (YYYY-MM-DD), no limit by default"
+// @Param tz query string false "IANA time zone of from and to, profile time zone by default"
+// @Success 200 {file} file
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/export [get]
+func Export(c *gin.Context) {
+	// Последний день диапазона включается в выгрузку
+	fromDate, toDate, err := queryDateRange(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	var from, to time.Time
+	if fromDate != nil {
+		from = *fromDate
+	}
+	if toDate != nil {
+		to = *toDate
+	}
+
+	datasets, err := service.ResolveExportDatasets(c.Query("datasets"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	writer, err := exporter.NewWriter(c.DefaultQuery("format", models.ExportFormatJSON), c.Writer, datasets)
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	filename := fmt.Sprintf("coinkeeper-export-%s.%s", time.Now().Format("20060102"), writer.Extension())
+	c.Header("Content-Type", writer.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+	if err = service.Export(c.GetUint(userIDCtx), from, to, datasets, writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			handleError(c, err)
+			return
+		}
+		// Часть выгрузки уже отправлена, поэтому ошибку можно только записать в лог
+		logger.Error.Println("[controllers.Export] export interrupted. Error is:", err.Error())
+		c.Abort()
+	}
+}
@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllGoals
+// @Summary Get All Goals
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description get list of all savings goals
+// @ID get-all-goals
+// @Produce json
+// @Success 200 {array} models.Goal
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals [get]
+func GetAllGoals(c *gin.Context) {
+	userID := c.GetUint(userIDCtx)
+	goals, err := service.GetAllGoals(userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"goals": goals})
+}
+
+// GetGoalByID
+// @Summary Get Goal By ID
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description get savings goal by ID
+// @ID get-goal-by-id
+// @Produce json
+// @Param id path integer true "id of the goal"
+// @Success 200 {object} models.Goal
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id} [get]
+func GetGoalByID(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	goal, err := service.GetGoalByID(userID, uint(goalID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, goal)
+}
+
+// CreateGoal
+// @Summary Create Goal
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description create new savings goal
+// @ID create-goal
+// @Accept json
+// @Produce json
+// @Param input body models.GoalInput true "new goal info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals [post]
+func CreateGoal(c *gin.Context) {
+	var input models.GoalInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	goal := input.Goal()
+	goal.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateGoal(goal); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("goal created successfully"))
+}
+
+// UpdateGoal
+// @Summary Update Goal
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description update title, target amount and deadline of the goal
+// @ID update-goal
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the goal"
+// @Param input body models.GoalUpdateInput true "goal update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id} [put]
+func UpdateGoal(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.GoalUpdateInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	goal := input.Goal()
+	goal.ID = uint(goalID)
+	goal.UserID = c.GetUint(userIDCtx)
+	if err = service.UpdateGoal(goal); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("goal updated successfully"))
+}
+
+// DeleteGoal
+// @Summary Delete Goal By ID
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description delete savings goal by ID
+// @ID delete-goal-by-id
+// @Param id path integer true "id of the goal"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id} [delete]
+func DeleteGoal(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteGoal(uint(goalID), userID); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("goal deleted successfully"))
+}
+
+// GetGoalContributions
+// @Summary Get Goal Contributions
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description get list of contributions to the goal
+// @ID get-goal-contributions
+// @Produce json
+// @Param id path integer true "id of the goal"
+// @Success 200 {array} models.GoalContribution
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id}/contributions [get]
+func GetGoalContributions(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	contributions, err := service.GetGoalContributions(userID, uint(goalID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"contributions": contributions})
+}
+
+// ContributeToGoal
+// @Summary Contribute To Goal
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description add money to the goal, optionally debiting it from a card
+// @ID contribute-to-goal
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the goal"
+// @Param input body models.GoalContributionInput true "contribution info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id}/contributions [post]
+func ContributeToGoal(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.GoalContributionInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	contribution := input.GoalContribution()
+	contribution.GoalID = uint(goalID)
+	contribution.UserID = c.GetUint(userIDCtx)
+	if err = service.ContributeToGoal(contribution, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("contribution added successfully"))
+}
+
+// GetGoalProgress
+// @Summary Get Goal Progress
+// @Security ApiKeyAuth
+// @Tags goals
+// @Description get percentage, required monthly contribution and projected completion date of the goal
+// @ID get-goal-progress
+// @Produce json
+// @Param id path integer true "id of the goal"
+// @Success 200 {object} models.GoalProgress
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/goals/{id}/progress [get]
+func GetGoalProgress(c *gin.Context) {
+	goalID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	progress, err := service.GetGoalProgress(userID, uint(goalID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/importer"
+	"coinkeeper/pkg/service"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// maxImportFileSize — максимальный размер загружаемой выписки
+const maxImportFileSize = 10 << 20
+
+// PreviewImport
+// @Summary Preview Statement Import
+// @Security ApiKeyAuth
+// @Tags import
+// @Description parse a CSV, OFX or QIF bank statement and show which rows would be imported, which are duplicates and which have errors
+// @ID preview-import
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "bank statement"
+// @Param format formData string false "csv, ofx or qif, detected by file extension by default"
+// @Param card_id formData integer false "card of the statement; without a card rows are imported as transactions without a card"
+// @Param category_id formData integer false "category for debits without a category in the statement"
+// @Param currency formData string false "currency of the statement, currency of the card by default"
+// @Param mapping formData string false "CSV column mapping as JSON, see models.CSVMapping"
+// @Success 200 {object} models.ImportReport
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/import/preview [post]
+func PreviewImport(c *gin.Context) {
+	handleImport(c, service.PreviewImport)
+}
+
+// ImportStatement
+// @Summary Import Statement
+// @Security ApiKeyAuth
+// @Tags import
+// @Description import a CSV, OFX or QIF bank statement; duplicates and rows with errors are skipped and listed in the report
+// @ID import-statement
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "bank statement"
+// @Param format formData string false "csv, ofx or qif, detected by file extension by default"
+// @Param card_id formData integer false "card of the statement; without a card rows are imported as transactions without a card"
+// @Param category_id formData integer false "category for debits without a category in the statement"
+// @Param currency formData string false "currency of the statement, currency of the card by default"
+// @Param mapping formData string false "CSV column mapping as JSON, see models.CSVMapping"
+// @Success 200 {object} models.ImportReport
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/import [post]
+func ImportStatement(c *gin.Context) {
+	handleImport(c, service.ImportStatement)
+}
+
+func handleImport(c *gin.Context, run func(service.ImportOptions, io.Reader) (models.ImportReport, error)) {
+	header, err := c.FormFile("file")
+	if err != nil || header.Size > maxImportFileSize {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	options := service.ImportOptions{
+		UserID:   c.GetUint(userIDCtx),
+		Format:   c.PostForm("format"),
+		Currency: c.PostForm("currency"),
+		Audit:    auditMeta(c),
+	}
+	if options.Format == "" {
+		options.Format = importer.DetectFormat(header.Filename)
+	}
+	if options.CardID, err = optionalFormID(c, "card_id"); err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+	if options.CategoryID, err = optionalFormID(c, "category_id"); err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		if err = json.Unmarshal([]byte(mapping), &options.Mapping); err != nil {
+			handleError(c, errs.ErrValidationFailed)
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	report, err := run(options, file)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func optionalFormID(c *gin.Context, key string) (*uint, error) {
+	return parseOptionalID(c.PostForm(key))
+}
+
+// parseOptionalID разбирает необязательный идентификатор: пустая строка — nil, ноль и мусор — ошибка
+func parseOptionalID(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return nil, errs.ErrValidationFailed
+	}
+	result := uint(id)
+	return &result, nil
+}
@@ -16,37 +16,43 @@ import (
 // @Description get list of all income
 // @ID get-all-incomes
 // @Produce json
-// @Param q query string false "fill if you need search"
-// @Success 200 {array} models.Income
+// @Param q query string false "search in description"
+// @Param card_id query string false "card IDs separated by commas"
+// @Param category_id query string false "category IDs separated by commas"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, e.g. Asia/Dushanbe, profile time zone by default"
+// @Param min_amount query string false "minimal amount, e.g. 10.50"
+// @Param max_amount query string false "maximal amount, e.g. 100"
+// @Param currency query string false "only transactions in the currency"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param sort query string false "date, amount or category, date by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} incomeList
 // @Failure 400 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/income [get]
 func GetAllIncome(c *gin.Context) {
-	query := c.Query("q")
-
-	userID := c.GetUint(userIDCtx)
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	filter, err := bindTransactionFilter(c)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
-	income, err := service.GetAllIncome(userID, query)
+	params, err := bindListParams(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"income": income})
 
-	//income, err := service.GetAllIncome()
-	//if err != nil {
-	//	c.JSON(http.StatusInternalServerError, gin.H{
-	//		"error": err.Error(),
-	//	})
-	//	return
-	//}
-	//c.JSON(http.StatusOK, gin.H{
-	//	"income": income,
-	//})
+	userID := c.GetUint(userIDCtx)
+	income, page, err := service.GetAllIncome(userID, filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, incomeList{Income: income, Pagination: page})
 }
 
 // GetIncomeByID
@@ -58,20 +64,21 @@ func GetAllIncome(c *gin.Context) {
 // @Produce json
 // @Param id path integer true "id of the income"
 // @Success 200 {object} models.Income
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/incomes/{id} [get]
 func GetIncomeByID(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
 	incomeID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
+
+	userID := c.GetUint(userIDCtx)
 	income, err := service.GetIncomeByID(userID, uint(incomeID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, income)
@@ -85,18 +92,20 @@ func GetIncomeByID(c *gin.Context) {
 // @ID create-new-income
 // @Accept json
 // @Produce json
-// @Param input body models.Income true "new income info"
+// @Param input body models.IncomeInput true "new income info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/incomes [post]
 func CreateIncome(c *gin.Context) {
-	var income models.Income
-	if err := c.BindJSON(&income); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.IncomeInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	income := input.Income()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -104,7 +113,7 @@ func CreateIncome(c *gin.Context) {
 		return
 	}
 	income.UserID = userID
-	if err := service.CreateIncome(income); err != nil {
+	if err := service.CreateIncome(income, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -120,9 +129,10 @@ func CreateIncome(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path integer true "id of the income"
-// @Param input body models.Income true "income update info"
+// @Param input body models.IncomeUpdateInput true "income update info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/incomes/{id} [put]
@@ -133,11 +143,12 @@ func UpdateIncome(c *gin.Context) {
 		return
 	}
 
-	var income models.Income
-	if err = c.BindJSON(&income); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.IncomeUpdateInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	income := input.Income()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -146,7 +157,7 @@ func UpdateIncome(c *gin.Context) {
 	}
 	income.ID = uint(incomeID)
 	income.UserID = userID
-	if err = service.UpdateIncome(income); err != nil {
+	if err = service.UpdateIncome(income, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -161,7 +172,7 @@ func UpdateIncome(c *gin.Context) {
 // @ID delete-income-by-id
 // @Param id path integer true "id of the income"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/incomes/{id} [delete]
@@ -177,9 +188,36 @@ func DeleteIncome(c *gin.Context) {
 		return
 	}
 
-	if err = service.DeleteIncome(incomeID, uint(userID)); err != nil {
+	if err = service.DeleteIncome(incomeID, uint(userID), auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, defaultResponse{Message: "income deleted successfully"})
 }
+
+// RestoreIncome
+// @Summary Restore Income By ID
+// @Security ApiKeyAuth
+// @Tags incomes
+// @Description restore deleted income from the trash; the card must not be in the trash
+// @ID restore-income-by-id
+// @Param id path integer true "id of the income"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/income/{id}/restore [post]
+func RestoreIncome(c *gin.Context) {
+	incomeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.RestoreIncome(uint(incomeID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("income restored successfully"))
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bindListParams читает page, limit, sort и order из строки запроса
+func bindListParams(c *gin.Context) (params models.ListParams, err error) {
+	if value := c.Query("page"); value != "" {
+		if params.Page, err = strconv.Atoi(value); err != nil || params.Page < 1 {
+			return params, errs.ErrValidationFailed
+		}
+	}
+	if value := c.Query("limit"); value != "" {
+		if params.Limit, err = strconv.Atoi(value); err != nil || params.Limit < 1 {
+			return params, errs.ErrValidationFailed
+		}
+	}
+	params.Sort = c.Query("sort")
+	params.Order = c.Query("order")
+	return params, nil
+}
+
+// bindTransactionFilter читает фильтры списков операций: type, card_id, category_id, tag, q,
+// from, to, tz, min_amount, max_amount и currency
+func bindTransactionFilter(c *gin.Context) (filter models.TransactionFilter, err error) {
+	filter = models.TransactionFilter{
+		Type:     c.Query("type"),
+		Tag:      c.Query("tag"),
+		Query:    c.Query("q"),
+		Currency: c.Query("currency"),
+	}
+	if filter.CardIDs, err = queryIDs(c, "card_id"); err != nil {
+		return filter, err
+	}
+	if filter.CategoryIDs, err = queryIDs(c, "category_id"); err != nil {
+		return filter, err
+	}
+	if filter.From, filter.To, err = queryDateRange(c); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount, err = queryAmount(c, "min_amount", filter.Currency); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = queryAmount(c, "max_amount", filter.Currency); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// bindCardFilter читает фильтры списка карт: id, from, to, tz, min_amount, max_amount и currency
+func bindCardFilter(c *gin.Context) (filter models.CardFilter, err error) {
+	filter.Currency = c.Query("currency")
+	if filter.IDs, err = queryIDs(c, "id"); err != nil {
+		return filter, err
+	}
+	if filter.From, filter.To, err = queryDateRange(c); err != nil {
+		return filter, err
+	}
+	if filter.MinBalance, err = queryAmount(c, "min_amount", filter.Currency); err != nil {
+		return filter, err
+	}
+	if filter.MaxBalance, err = queryAmount(c, "max_amount", filter.Currency); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// queryIDs читает список идентификаторов, переданный через запятую (?card_id=1,2)
+// или повтором параметра (?card_id=1&card_id=2)
+func queryIDs(c *gin.Context, key string) ([]uint, error) {
+	var ids []uint
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			id, err := parseOptionalID(strings.TrimSpace(part))
+			if err != nil || id == nil {
+				return nil, errs.ErrValidationFailed
+			}
+			ids = append(ids, *id)
+		}
+	}
+	return ids, nil
+}
+
+// queryDateRange читает диапазон дат from и to (YYYY-MM-DD) в зоне tz; последний день включается в диапазон
+func queryDateRange(c *gin.Context) (from, to *time.Time, err error) {
+	location, err := queryLocation(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if value := c.Query("from"); value != "" {
+		date, err := time.ParseInLocation(reportDateLayout, value, location)
+		if err != nil {
+			return nil, nil, errs.ErrValidationFailed
+		}
+		from = &date
+	}
+	if value := c.Query("to"); value != "" {
+		date, err := time.ParseInLocation(reportDateLayout, value, location)
+		if err != nil {
+			return nil, nil, errs.ErrValidationFailed
+		}
+		date = date.AddDate(0, 0, 1)
+		to = &date
+	}
+	return from, to, nil
+}
+
+// queryLocation читает IANA-зону из параметра tz, в которой клиент задаёт даты и периоды.
+// Без параметра используется зона из профиля пользователя, а если она не задана — зона сервера.
+func queryLocation(c *gin.Context) (*time.Location, error) {
+	value := c.Query("tz")
+	if value == "" {
+		return service.GetUserLocation(c.GetUint(userIDCtx))
+	}
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, errs.ErrValidationFailed
+	}
+	return location, nil
+}
+
+// queryAmount читает десятичную сумму и переводит её в минорные единицы валюты currency
+// (по умолчанию — models.DefaultCurrency)
+func queryAmount(c *gin.Context, key, currency string) (*int64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	amount, err := models.ParseMoney(value, currency)
+	if err != nil {
+		return nil, errs.ErrValidationFailed
+	}
+	return &amount.Minor, nil
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetLoginAttempts
+// @Summary Get Failed Login Attempts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description get a page of the failed login audit trail, newest first by default, admin only
+// @ID admin-get-login-attempts
+// @Produce json
+// @Param username query string false "only attempts with the username"
+// @Param ip query string false "only attempts from the IP address"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, profile time zone by default"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} loginAttemptList
+// @Failure 400 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/login-attempts [get]
+func GetLoginAttempts(c *gin.Context) {
+	filter := models.LoginAttemptFilter{
+		Username:  c.Query("username"),
+		IPAddress: c.Query("ip"),
+	}
+	var err error
+	if filter.From, filter.To, err = queryDateRange(c); err != nil {
+		handleError(c, err)
+		return
+	}
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	attempts, page, err := service.GetLoginAttempts(filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, loginAttemptList{LoginAttempts: attempts, Pagination: page})
+}
@@ -1,18 +1,47 @@
 package controllers
 
 import (
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
 	"coinkeeper/pkg/service"
+	"coinkeeper/utils"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"regexp"
 	"strings"
 )
 
 const (
 	authorizationHeader = "Authorization"
 	userIDCtx           = "userID"
+	userRoleCtx         = "userRole"
+	tokenClaimsCtx      = "tokenClaims"
+	requestIDHeader     = "X-Request-ID"
+	requestIDCtx        = "requestID"
 )
 
+// requestIDPattern — допустимый идентификатор запроса, переданный клиентом или прокси
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// setRequestID берёт идентификатор запроса из заголовка X-Request-ID или создаёт новый
+// и возвращает его в ответе. По нему записи журнала изменений связываются с логами прокси.
+func setRequestID(c *gin.Context) {
+	requestID := c.GetHeader(requestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		var err error
+		if requestID, err = utils.RandomID(16); err != nil {
+			logger.Error.Println("[controllers.setRequestID] cannot generate request id. Error is:", err.Error())
+			requestID = ""
+		}
+	}
+	c.Set(requestIDCtx, requestID)
+	c.Header(requestIDHeader, requestID)
+	c.Next()
+}
+
 func checkUserAuthentication(c *gin.Context) {
 	header := c.GetHeader(authorizationHeader)
 
@@ -40,13 +69,51 @@ func checkUserAuthentication(c *gin.Context) {
 
 	accessToken := headerParts[1]
 
-	claims, err := service.ParseToken(accessToken)
+	claims, err := service.ValidateAccessToken(accessToken)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		if errors.Is(err, errs.ErrInvalidToken) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, newErrorResponse(errs.ErrSomethingWentWrong.Error()))
+		}
 		return
 	}
 	fmt.Println(claims)
 
+	// Токены, выданные до появления ролей, не содержат роли
+	role := claims.Role
+	if role == "" {
+		role = models.RoleUser
+	}
+
 	c.Set(userIDCtx, claims.UserID)
+	c.Set(userRoleCtx, role)
+	c.Set(tokenClaimsCtx, claims)
+	c.Next()
+}
+
+// checkUserRole пропускает запрос, только если роль из токена входит в roles.
+// Используется после checkUserAuthentication.
+func checkUserRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(userRoleCtx)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, newErrorResponse(errs.ErrPermissionDenied.Error()))
+	}
+}
+
+// checkTwoFactorSetup не пускает к API пользователя, который обязан подключить двухфакторную
+// аутентификацию и ещё не подключил. Используется после checkUserAuthentication.
+func checkTwoFactorSetup(c *gin.Context) {
+	claims := c.MustGet(tokenClaimsCtx).(*service.CustomClaims)
+	if claims.TwoFactorSetup {
+		c.AbortWithStatusJSON(http.StatusForbidden, newErrorResponse(errs.ErrTwoFactorRequired.Error()))
+		return
+	}
 	c.Next()
 }
@@ -16,37 +16,43 @@ import (
 // @Description get list of all outcome
 // @ID get-all-outcome
 // @Produce json
-// @Param q query string false "fill if you need search"
-// @Success 200 {array} models.Outcome
+// @Param q query string false "search in description"
+// @Param card_id query string false "card IDs separated by commas"
+// @Param category_id query string false "category IDs separated by commas"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, e.g. Asia/Dushanbe, profile time zone by default"
+// @Param min_amount query string false "minimal amount, e.g. 10.50"
+// @Param max_amount query string false "maximal amount, e.g. 100"
+// @Param currency query string false "only transactions in the currency"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param sort query string false "date, amount or category, date by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} outcomeList
 // @Failure 400 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/outcome [get]
 func GetAllOutcome(c *gin.Context) {
-	query := c.Query("q")
-
-	userID := c.GetUint(userIDCtx)
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	filter, err := bindTransactionFilter(c)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
-	outcome, err := service.GetAllOutcome(userID, query)
+	params, err := bindListParams(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"outcome": outcome})
 
-	//outcome, err := service.GetAllOutcome()
-	//if err != nil {
-	//	c.JSON(http.StatusInternalServerError, gin.H{
-	//		"error": err.Error(),
-	//	})
-	//	return
-	//}
-	//c.JSON(http.StatusOK, gin.H{
-	//	"outcome": outcome,
-	//})
+	userID := c.GetUint(userIDCtx)
+	outcome, page, err := service.GetAllOutcome(userID, filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, outcomeList{Outcome: outcome, Pagination: page})
 }
 
 // GetOutcomeByID
@@ -58,20 +64,21 @@ func GetAllOutcome(c *gin.Context) {
 // @Produce json
 // @Param id path integer true "id of the outcome"
 // @Success 200 {object} models.Outcome
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/outcomes/{id} [get]
 func GetOutcomeByID(c *gin.Context) {
-	userID := c.GetUint(userIDCtx)
 	outcomeID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
+
+	userID := c.GetUint(userIDCtx)
 	outcome, err := service.GetOutcomeByID(userID, uint(outcomeID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, outcome)
@@ -85,18 +92,20 @@ func GetOutcomeByID(c *gin.Context) {
 // @ID create-new-outcome
 // @Accept json
 // @Produce json
-// @Param input body models.Outcome true "new outcome info"
+// @Param input body models.OutcomeInput true "new outcome info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/outcomes [post]
 func CreateOutcome(c *gin.Context) {
-	var outcome models.Outcome
-	if err := c.BindJSON(&outcome); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.OutcomeInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	outcome := input.Outcome()
 
 	userID := c.GetUint(userIDCtx)
 	if userID == 0 {
@@ -104,7 +113,7 @@ func CreateOutcome(c *gin.Context) {
 		return
 	}
 	outcome.UserID = userID
-	if err := service.CreateOutcome(outcome); err != nil {
+	if err := service.CreateOutcome(outcome, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -120,9 +129,10 @@ func CreateOutcome(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path integer true "id of the outcome"
-// @Param input body models.Outcome true "outcome update info"
+// @Param input body models.OutcomeInput true "outcome update info"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/outcomes/{id} [put]
@@ -133,11 +143,12 @@ func UpdateOutcome(c *gin.Context) {
 		return
 	}
 
-	var outcome models.Outcome
-	if err = c.BindJSON(&outcome); err != nil {
-		handleError(c, errs.ErrValidationFailed)
+	var input models.OutcomeInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	outcome := input.Outcome()
 
 	outcome.ID = uint(outcomeID)
 
@@ -148,7 +159,7 @@ func UpdateOutcome(c *gin.Context) {
 	}
 	outcome.ID = uint(outcomeID)
 	outcome.UserID = userID
-	if err = service.UpdateOutcome(outcome); err != nil {
+	if err = service.UpdateOutcome(outcome, auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -163,7 +174,7 @@ func UpdateOutcome(c *gin.Context) {
 // @ID delete-outcome-by-id
 // @Param id path integer true "id of the outcome"
 // @Success 200 {object} defaultResponse
-// @Failure 400 404 {object} ErrorResponse
+// @Failure 400 403 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure default {object} ErrorResponse
 // @Router /api/outcomes/{id} [delete]
@@ -179,9 +190,36 @@ func DeleteOutcome(c *gin.Context) {
 		return
 	}
 
-	if err = service.DeleteOutcome(outcomeID, uint(userID)); err != nil {
+	if err = service.DeleteOutcome(outcomeID, uint(userID), auditMeta(c)); err != nil {
 		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, defaultResponse{Message: "outcome deleted successfully"})
 }
+
+// RestoreOutcome
+// @Summary Restore Outcome By ID
+// @Security ApiKeyAuth
+// @Tags outcomes
+// @Description restore deleted outcome from the trash
+// @ID restore-outcome-by-id
+// @Param id path integer true "id of the outcome"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/outcome/{id}/restore [post]
+func RestoreOutcome(c *gin.Context) {
+	outcomeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.RestoreOutcome(uint(outcomeID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("outcome restored successfully"))
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"coinkeeper/pkg/testdb"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// asUser подставляет пользователя вместо проверки токена
+func asUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(userIDCtx, userID)
+	}
+}
+
+// Своя запись — 200, чужая — 403, несуществующая — 404, нечисловой id — 400
+func TestGetByIDStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testdb.Open(t)
+	owner := testdb.CreateUser(t, "owner")
+	intruder := testdb.CreateUser(t, "intruder")
+	card := testdb.CreateCard(t, owner.ID, models.NewMoney(100000, "TJS"))
+
+	category := models.Category{Title: "Groceries", Type: models.CategoryTypeOutcome, UserID: &owner.ID}
+	if err := db.GetDBConn().Create(&category).Error; err != nil {
+		t.Fatalf("cannot create category: %v", err)
+	}
+	create := func(transaction models.Transaction) uint {
+		transaction.Amount = models.NewMoney(1000, "TJS")
+		transaction.Description = transaction.Type
+		transaction.UserID = owner.ID
+		if err := service.CreateTransaction(&transaction, models.AuditMeta{ActorID: owner.ID}); err != nil {
+			t.Fatalf("cannot create %s: %v", transaction.Type, err)
+		}
+		return transaction.ID
+	}
+	income := create(models.Transaction{Type: models.TransactionTypeIncome, CardID: &card.ID})
+	outcome := create(models.Transaction{Type: models.TransactionTypeExpense, CategoryID: &category.ID})
+	expense := create(models.Transaction{Type: models.TransactionTypeExpense, CardID: &card.ID, CategoryID: &category.ID})
+
+	routes := []struct {
+		path    string
+		handler gin.HandlerFunc
+		id      uint
+	}{
+		{"/cards/", GetCardByID, card.ID},
+		{"/incomes/", GetIncomeByID, income},
+		{"/outcomes/", GetOutcomeByID, outcome},
+		{"/expenses/", GetExpenseByID, expense},
+	}
+	for _, route := range routes {
+		tests := []struct {
+			name   string
+			userID uint
+			id     string
+			status int
+		}{
+			{"own", owner.ID, fmt.Sprint(route.id), http.StatusOK},
+			{"another user's", intruder.ID, fmt.Sprint(route.id), http.StatusForbidden},
+			{"missing", owner.ID, "999999", http.StatusNotFound},
+			{"invalid id", owner.ID, "abc", http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			t.Run(route.path+tt.name, func(t *testing.T) {
+				r := gin.New()
+				r.GET(route.path+":id", asUser(tt.userID), route.handler)
+				recorder := httptest.NewRecorder()
+				r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, route.path+tt.id, nil))
+				if recorder.Code != tt.status {
+					t.Errorf("GET %s%s status = %d, want %d: %s", route.path, tt.id, recorder.Code, tt.status, recorder.Body)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ChangePassword
+// @Summary Change Password
+// @Security ApiKeyAuth
+// @Tags auth
+// @Description change the password of the current user; all sessions end and a new token pair for this client is returned
+// @ID change-password
+// @Accept json
+// @Produce json
+// @Param input body models.ChangePasswordInput true "current and new password, the new one 8 to 128 characters long"
+// @Success 200 {object} models.TokenPair
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/password [put]
+func ChangePassword(c *gin.Context) {
+	var input models.ChangePasswordInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	tokens, err := service.ChangePassword(c.GetUint(userIDCtx), input.CurrentPassword, input.NewPassword)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// ForgotPassword
+// @Summary Forgot Password
+// @Tags auth
+// @Description send a single-use password reset link to the email of the account; the response is the same whether the email is registered or not
+// @ID forgot-password
+// @Accept json
+// @Produce json
+// @Param input body models.ForgotPasswordInput true "email of the account"
+// @Success 200 {object} defaultResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/forgot-password [post]
+func ForgotPassword(c *gin.Context) {
+	var input models.ForgotPasswordInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := service.ForgotPassword(input.Email); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("if the email is registered, a password reset link has been sent to it"))
+}
+
+// ResetPassword
+// @Summary Reset Password
+// @Tags auth
+// @Description set a new password with the token from the reset email; the token works once and all sessions of the user end
+// @ID reset-password
+// @Accept json
+// @Produce json
+// @Param input body models.ResetPasswordInput true "reset token and new password 8 to 128 characters long"
+// @Success 200 {object} defaultResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /auth/reset-password [post]
+func ResetPassword(c *gin.Context) {
+	var input models.ResetPasswordInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := service.ResetPassword(input.Token, input.NewPassword); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("password reset successfully"))
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetProfile
+// @Summary Get Profile
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description get the profile of the current user
+// @ID get-profile
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me [get]
+func GetProfile(c *gin.Context) {
+	user, err := service.GetProfile(c.GetUint(userIDCtx))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile
+// @Summary Update Profile
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description change full name, base currency, locale or time zone of the current user; empty fields are kept. The time zone is the default tz of lists, reports and export
+// @ID update-profile
+// @Accept json
+// @Produce json
+// @Param input body models.ProfileInput true "profile fields to change"
+// @Success 200 {object} models.User
+// @Failure 400 401 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me [put]
+func UpdateProfile(c *gin.Context) {
+	var input models.ProfileInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	user, err := service.UpdateProfile(c.GetUint(userIDCtx), input)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteAccount
+// @Summary Delete Account
+// @Security ApiKeyAuth
+// @Tags profile
+// @Description delete the account of the current user: all sessions end at once, and the account with all cards, transactions and other data is erased after the grace period
+// @ID delete-account
+// @Accept json
+// @Produce json
+// @Param input body models.DeleteAccountInput true "password and, with two-factor authentication, a code"
+// @Success 200 {object} defaultResponse
+// @Failure 400 401 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me [delete]
+func DeleteAccount(c *gin.Context) {
+	var input models.DeleteAccountInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := service.DeleteAccount(c.GetUint(userIDCtx), input.Password, input.Code); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("account deleted successfully"))
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllExchangeRates
+// @Summary Get All Exchange Rates
+// @Security ApiKeyAuth
+// @Tags rates
+// @Description get list of all exchange rates of the user
+// @ID get-all-exchange-rates
+// @Produce json
+// @Success 200 {array} models.ExchangeRate
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/rates [get]
+func GetAllExchangeRates(c *gin.Context) {
+	userID := c.GetUint(userIDCtx)
+	rates, err := service.GetAllExchangeRates(userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rates": rates})
+}
+
+// GetExchangeRateByID
+// @Summary Get Exchange Rate By ID
+// @Security ApiKeyAuth
+// @Tags rates
+// @Description get exchange rate by ID
+// @ID get-exchange-rate-by-id
+// @Produce json
+// @Param id path integer true "id of the exchange rate"
+// @Success 200 {object} models.ExchangeRate
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/rates/{id} [get]
+func GetExchangeRateByID(c *gin.Context) {
+	rateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	rate, err := service.GetExchangeRateByID(userID, uint(rateID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rate)
+}
+
+// CreateExchangeRate
+// @Summary Create Exchange Rate
+// @Security ApiKeyAuth
+// @Tags rates
+// @Description create new exchange rate: 1 base_currency = rate quote_currency
+// @ID create-exchange-rate
+// @Accept json
+// @Produce json
+// @Param input body models.ExchangeRateInput true "new exchange rate info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/rates [post]
+func CreateExchangeRate(c *gin.Context) {
+	var input models.ExchangeRateInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	rate := input.ExchangeRate()
+	rate.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateExchangeRate(rate); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("exchange rate created successfully"))
+}
+
+// UpdateExchangeRate
+// @Summary Update Exchange Rate
+// @Security ApiKeyAuth
+// @Tags rates
+// @Description update existed exchange rate
+// @ID update-exchange-rate
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the exchange rate"
+// @Param input body models.ExchangeRateInput true "exchange rate update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/rates/{id} [put]
+func UpdateExchangeRate(c *gin.Context) {
+	rateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.ExchangeRateInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	rate := input.ExchangeRate()
+	rate.ID = uint(rateID)
+	rate.UserID = c.GetUint(userIDCtx)
+	if err = service.UpdateExchangeRate(rate); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("exchange rate updated successfully"))
+}
+
+// DeleteExchangeRate
+// @Summary Delete Exchange Rate By ID
+// @Security ApiKeyAuth
+// @Tags rates
+// @Description delete exchange rate by ID
+// @ID delete-exchange-rate-by-id
+// @Param id path integer true "id of the exchange rate"
+// @Success 200 {object} defaultResponse
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/rates/{id} [delete]
+func DeleteExchangeRate(c *gin.Context) {
+	rateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteExchangeRate(uint(rateID), userID); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("exchange rate deleted successfully"))
+}
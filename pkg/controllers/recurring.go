@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllRecurringRules
+// @Summary Get All Recurring Rules
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description get list of all recurring transaction rules
+// @ID get-all-recurring-rules
+// @Produce json
+// @Success 200 {array} models.RecurringRule
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring [get]
+func GetAllRecurringRules(c *gin.Context) {
+	userID := c.GetUint(userIDCtx)
+	rules, err := service.GetAllRecurringRules(userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// GetRecurringRuleByID
+// @Summary Get Recurring Rule By ID
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description get recurring transaction rule by ID
+// @ID get-recurring-rule-by-id
+// @Produce json
+// @Param id path integer true "id of the rule"
+// @Success 200 {object} models.RecurringRule
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring/{id} [get]
+func GetRecurringRuleByID(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	rule, err := service.GetRecurringRuleByID(userID, uint(ruleID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRecurringRule
+// @Summary Create Recurring Rule
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description create rule for a recurring income, outcome or expense
+// @ID create-recurring-rule
+// @Accept json
+// @Produce json
+// @Param input body models.RecurringRuleInput true "new rule info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring [post]
+func CreateRecurringRule(c *gin.Context) {
+	var input models.RecurringRuleInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	rule := input.RecurringRule()
+	rule.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateRecurringRule(rule); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("recurring rule created successfully"))
+}
+
+// UpdateRecurringRule
+// @Summary Update Recurring Rule
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description update existed recurring transaction rule
+// @ID update-recurring-rule
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the rule"
+// @Param input body models.RecurringRuleInput true "rule update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring/{id} [put]
+func UpdateRecurringRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.RecurringRuleInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	rule := input.RecurringRule()
+	rule.ID = uint(ruleID)
+	rule.UserID = c.GetUint(userIDCtx)
+	if err = service.UpdateRecurringRule(rule); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("recurring rule updated successfully"))
+}
+
+// DeleteRecurringRule
+// @Summary Delete Recurring Rule By ID
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description delete recurring transaction rule by ID, already created transactions are kept
+// @ID delete-recurring-rule-by-id
+// @Param id path integer true "id of the rule"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring/{id} [delete]
+func DeleteRecurringRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteRecurringRule(uint(ruleID), userID); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("recurring rule deleted successfully"))
+}
+
+// PreviewRecurringRule
+// @Summary Preview Recurring Rule
+// @Security ApiKeyAuth
+// @Tags recurring
+// @Description dry run: list upcoming transactions of the rule without creating them
+// @ID preview-recurring-rule
+// @Produce json
+// @Param id path integer true "id of the rule"
+// @Param count query integer false "number of transactions to show, 10 by default"
+// @Success 200 {array} models.RecurringPreviewItem
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/recurring/{id}/preview [get]
+func PreviewRecurringRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "10"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	items, err := service.PreviewRecurringRule(userID, uint(ruleID), count)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transactions": items})
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// GetReportSummary
+// @Summary Get Report Summary
+// @Security ApiKeyAuth
+// @Tags reports
+// @Description get income, outcome and net for a date range grouped by period, category and card
+// @ID get-report-summary
+// @Produce json
+// @Param from query string false "first day of the range (YYYY-MM-DD), first day of the current month by default"
+// @Param to query string false "last day of the range (YYYY-MM-DD), today by default"
+// @Param group_by query string false "day, week, month or year, month by default"
+// @Param currency query string false "currency of the report, base currency of the user by default"
+// @Param tz query string false "IANA time zone of days and periods, e.g. Asia/Dushanbe, profile time zone by default"
+// @Success 200 {object} models.ReportSummary
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/reports/summary [get]
+func GetReportSummary(c *gin.Context) {
+	location, err := queryLocation(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	now := time.Now().In(location)
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	if value := c.Query("from"); value != "" {
+		if from, err = time.ParseInLocation(reportDateLayout, value, location); err != nil {
+			handleError(c, errs.ErrValidationFailed)
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.ParseInLocation(reportDateLayout, value, location); err != nil {
+			handleError(c, errs.ErrValidationFailed)
+			return
+		}
+	}
+
+	userID := c.GetUint(userIDCtx)
+	// Последний день диапазона включается в отчёт
+	summary, err := service.GetReportSummary(userID, from, to.AddDate(0, 0, 1), c.DefaultQuery("group_by", "month"), c.Query("currency"), location)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
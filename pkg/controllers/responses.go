@@ -1,5 +1,7 @@
 package controllers
 
+import "coinkeeper/models"
+
 type defaultResponse struct {
 	Message string `json:"message"`
 }
@@ -10,6 +12,44 @@ func newDefaultResponse(message string) defaultResponse {
 	}
 }
 
-type accessTokenResponse struct {
-	AccessToken string `json:"access_token"`
+// Ответы списков: строки текущей страницы и метаданные страницы
+
+type transactionList struct {
+	Transactions []models.Transaction `json:"transactions"`
+	Pagination   models.PageInfo      `json:"pagination"`
+}
+
+type incomeList struct {
+	Income     []models.Income `json:"income"`
+	Pagination models.PageInfo `json:"pagination"`
+}
+
+type outcomeList struct {
+	Outcome    []models.Outcome `json:"outcome"`
+	Pagination models.PageInfo  `json:"pagination"`
+}
+
+type expenseList struct {
+	Expenses   []models.Expense `json:"expenses"`
+	Pagination models.PageInfo  `json:"pagination"`
+}
+
+type cardList struct {
+	Cards      []models.Card   `json:"cards"`
+	Pagination models.PageInfo `json:"pagination"`
+}
+
+type loginAttemptList struct {
+	LoginAttempts []models.LoginAttempt `json:"login_attempts"`
+	Pagination    models.PageInfo       `json:"pagination"`
+}
+
+type auditList struct {
+	AuditEntries []models.AuditEntry `json:"audit_entries"`
+	Pagination   models.PageInfo     `json:"pagination"`
+}
+
+type trashList struct {
+	Items      []models.TrashItem `json:"items"`
+	Pagination models.PageInfo    `json:"pagination"`
 }
@@ -3,6 +3,8 @@ package controllers
 import (
 	"coinkeeper/configs"
 	_ "coinkeeper/docs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -10,8 +12,16 @@ import (
 )
 
 func InitRoutes() *gin.Engine {
+	registerValidations()
 	r := gin.Default()
+	r.Use(setRequestID)
 	gin.SetMode(configs.AppSettings.AppParams.GinMode)
+	// По IP клиента считаются неудачные входы, поэтому X-Forwarded-For принимается
+	// только от настроенных прокси
+	if err := r.SetTrustedProxies(configs.AppSettings.AppParams.TrustedProxies); err != nil {
+		logger.Error.Println("[controllers.InitRoutes] invalid trusted proxies, trusting none. Error is:", err.Error())
+		_ = r.SetTrustedProxies(nil)
+	}
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	r.GET("/ping", PingPong)
@@ -20,9 +30,43 @@ func InitRoutes() *gin.Engine {
 	{
 		auth.POST("/sign-up", SignUp)
 		auth.POST("/sign-in", SignIn)
+		auth.POST("/sign-in/2fa", SignInTwoFactor)
+		auth.POST("/refresh", Refresh)
+		auth.POST("/forgot-password", ForgotPassword)
+		auth.POST("/reset-password", ResetPassword)
+		auth.POST("/logout", checkUserAuthentication, Logout)
+		auth.POST("/logout-all", checkUserAuthentication, LogoutAll)
+	}
+
+	// Подключение двухфакторной аутентификации доступно и тем, кто обязан её подключить
+	twoFactorG := r.Group("/api/me/2fa", checkUserAuthentication)
+	{
+		twoFactorG.GET("", GetTwoFactorStatus)
+		twoFactorG.POST("/setup", SetupTwoFactor)
+		twoFactorG.POST("/enable", EnableTwoFactor)
+		twoFactorG.POST("/disable", DisableTwoFactor)
+		twoFactorG.POST("/recovery-codes", RegenerateRecoveryCodes)
+	}
+
+	apiG := r.Group("/api", checkUserAuthentication, checkTwoFactorSetup)
+
+	meG := apiG.Group("/me")
+	{
+		meG.GET("", GetProfile)
+		meG.PUT("", UpdateProfile)
+		meG.DELETE("", DeleteAccount)
+		meG.PUT("/password", ChangePassword)
 	}
 
-	apiG := r.Group("/api", checkUserAuthentication)
+	transactionG := apiG.Group("/transactions")
+	{
+		transactionG.GET("", GetAllTransactions)
+		transactionG.POST("", CreateTransaction)
+		transactionG.GET("/:id", GetTransactionByID)
+		transactionG.PUT("/:id", UpdateTransaction)
+		transactionG.DELETE("/:id", DeleteTransaction)
+		transactionG.POST("/:id/restore", RestoreTransaction)
+	}
 
 	incomeG := apiG.Group("/income")
 	{
@@ -31,6 +75,7 @@ func InitRoutes() *gin.Engine {
 		incomeG.GET("/:id", GetIncomeByID)
 		incomeG.PUT("/:id", UpdateIncome)
 		incomeG.DELETE("/:id", DeleteIncome)
+		incomeG.POST("/:id/restore", RestoreIncome)
 	}
 
 	outcomeG := apiG.Group("/outcome")
@@ -40,6 +85,7 @@ func InitRoutes() *gin.Engine {
 		outcomeG.GET("/:id", GetOutcomeByID)
 		outcomeG.PUT("/:id", UpdateOutcome)
 		outcomeG.DELETE("/:id", DeleteOutcome)
+		outcomeG.POST("/:id/restore", RestoreOutcome)
 	}
 
 	expenseG := apiG.Group("/expenses")
@@ -49,15 +95,107 @@ func InitRoutes() *gin.Engine {
 		expenseG.GET("/:id", GetExpenseByID)
 		expenseG.PUT("/:id", UpdateExpense)
 		expenseG.DELETE("/:id", DeleteExpense)
+		expenseG.POST("/:id/restore", RestoreExpense)
+	}
+
+	categoryG := apiG.Group("/categories")
+	{
+		categoryG.GET("", GetAllCategories)
+		categoryG.POST("", CreateCategory)
+		categoryG.GET("/:id", GetCategoryByID)
+		categoryG.PUT("/:id", UpdateCategory)
+		categoryG.DELETE("/:id", DeleteCategory)
+		categoryG.POST("/:id/merge", MergeCategory)
 	}
 
 	cardG := apiG.Group("/cards")
 	{
 		cardG.GET("", GetAllCards)
 		cardG.POST("", CreateCard)
+		cardG.GET("/balance", GetCardsBalance)
 		cardG.GET("/:id", GetCardByID)
 		cardG.PUT("/:id", UpdateCardBalance)
 		cardG.DELETE("/:id", DeleteCard)
+		cardG.POST("/:id/restore", RestoreCard)
+	}
+
+	rateG := apiG.Group("/rates")
+	{
+		rateG.GET("", GetAllExchangeRates)
+		rateG.POST("", CreateExchangeRate)
+		rateG.GET("/:id", GetExchangeRateByID)
+		rateG.PUT("/:id", UpdateExchangeRate)
+		rateG.DELETE("/:id", DeleteExchangeRate)
+	}
+
+	transferG := apiG.Group("/transfers")
+	{
+		transferG.GET("", GetAllTransfers)
+		transferG.POST("", CreateTransfer)
+		transferG.GET("/:id", GetTransferByID)
+		transferG.POST("/:id/cancel", CancelTransfer)
+	}
+
+	budgetG := apiG.Group("/budgets")
+	{
+		budgetG.GET("", GetAllBudgets)
+		budgetG.POST("", CreateBudget)
+		budgetG.GET("/status", GetBudgetsStatus)
+		budgetG.GET("/:id", GetBudgetByID)
+		budgetG.PUT("/:id", UpdateBudget)
+		budgetG.DELETE("/:id", DeleteBudget)
+		budgetG.GET("/:id/status", GetBudgetStatus)
+	}
+
+	goalG := apiG.Group("/goals")
+	{
+		goalG.GET("", GetAllGoals)
+		goalG.POST("", CreateGoal)
+		goalG.GET("/:id", GetGoalByID)
+		goalG.PUT("/:id", UpdateGoal)
+		goalG.DELETE("/:id", DeleteGoal)
+		goalG.GET("/:id/contributions", GetGoalContributions)
+		goalG.POST("/:id/contributions", ContributeToGoal)
+		goalG.GET("/:id/progress", GetGoalProgress)
+	}
+
+	recurringG := apiG.Group("/recurring")
+	{
+		recurringG.GET("", GetAllRecurringRules)
+		recurringG.POST("", CreateRecurringRule)
+		recurringG.GET("/:id", GetRecurringRuleByID)
+		recurringG.PUT("/:id", UpdateRecurringRule)
+		recurringG.DELETE("/:id", DeleteRecurringRule)
+		recurringG.GET("/:id/preview", PreviewRecurringRule)
+	}
+
+	reportG := apiG.Group("/reports")
+	{
+		reportG.GET("/summary", GetReportSummary)
+	}
+
+	importG := apiG.Group("/import")
+	{
+		importG.POST("", ImportStatement)
+		importG.POST("/preview", PreviewImport)
+	}
+
+	apiG.GET("/export", Export)
+	apiG.GET("/audit", GetAuditEntries)
+	apiG.GET("/trash", GetTrash)
+
+	adminG := apiG.Group("/admin", checkUserRole(models.RoleAdmin))
+	{
+		adminUserG := adminG.Group("/users")
+		{
+			adminUserG.GET("", GetAllUsers)
+			adminUserG.POST("", CreateUser)
+			adminUserG.GET("/:id", GetUserByID)
+			adminUserG.PUT("/:id", UpdateUser)
+			adminUserG.DELETE("/:id", DeleteUser)
+			adminUserG.PUT("/:id/2fa", SetTwoFactorRequired)
+		}
+		adminG.GET("/login-attempts", GetLoginAttempts)
 	}
 
 	return r
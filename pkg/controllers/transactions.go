@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllTransactions
+// @Summary Get All Transactions
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description get a page of income, expenses and transfers of the user, newest first by default
+// @ID get-all-transactions
+// @Produce json
+// @Param type query string false "income, expense or transfer"
+// @Param tag query string false "only transactions with the tag"
+// @Param q query string false "search in description"
+// @Param card_id query string false "card IDs separated by commas"
+// @Param category_id query string false "category IDs separated by commas"
+// @Param from query string false "first day of the range (YYYY-MM-DD)"
+// @Param to query string false "last day of the range (YYYY-MM-DD)"
+// @Param tz query string false "IANA time zone of from and to, e.g. Asia/Dushanbe, profile time zone by default"
+// @Param min_amount query string false "minimal amount, e.g. 10.50"
+// @Param max_amount query string false "maximal amount, e.g. 100"
+// @Param currency query string false "only transactions in the currency"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param sort query string false "date, amount or category, date by default"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} transactionList
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions [get]
+func GetAllTransactions(c *gin.Context) {
+	filter, err := bindTransactionFilter(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	transactions, page, err := service.GetAllTransactions(userID, filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, transactionList{Transactions: transactions, Pagination: page})
+}
+
+// GetTransactionByID
+// @Summary Get Transaction By ID
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description get transaction by ID
+// @ID get-transaction-by-id
+// @Produce json
+// @Param id path integer true "id of the transaction"
+// @Success 200 {object} models.Transaction
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions/{id} [get]
+func GetTransactionByID(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	transaction, err := service.GetTransactionByID(userID, uint(transactionID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, transaction)
+}
+
+// CreateTransaction
+// @Summary Create Transaction
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description create income or expense; expense requires a category, date defaults to now, transfers are created via /api/transfers
+// @ID create-transaction
+// @Accept json
+// @Produce json
+// @Param input body models.TransactionInput true "new transaction info"
+// @Success 201 {object} models.Transaction
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions [post]
+func CreateTransaction(c *gin.Context) {
+	var input models.TransactionInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transaction := input.Transaction()
+	transaction.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateTransaction(&transaction, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, transaction)
+}
+
+// UpdateTransaction
+// @Summary Update Transaction
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description replace amount, description, card, category, date, time zone and tags of income or expense; type cannot be changed, omitted date and time zone are kept
+// @ID update-transaction
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the transaction"
+// @Param input body models.TransactionInput true "transaction update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions/{id} [put]
+func UpdateTransaction(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.TransactionInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transaction := input.Transaction()
+
+	transaction.ID = uint(transactionID)
+	transaction.UserID = c.GetUint(userIDCtx)
+	if err = service.UpdateTransaction(transaction, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("transaction updated successfully"))
+}
+
+// DeleteTransaction
+// @Summary Delete Transaction By ID
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description delete income or expense by ID and roll back its effect on the card balance
+// @ID delete-transaction-by-id
+// @Param id path integer true "id of the transaction"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions/{id} [delete]
+func DeleteTransaction(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.DeleteTransaction(uint(transactionID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("transaction deleted successfully"))
+}
+
+// RestoreTransaction
+// @Summary Restore Transaction By ID
+// @Security ApiKeyAuth
+// @Tags transactions
+// @Description restore deleted income or expense from the trash and apply it to the card balance again; the card must not be in the trash
+// @ID restore-transaction-by-id
+// @Param id path integer true "id of the transaction"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transactions/{id}/restore [post]
+func RestoreTransaction(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.RestoreTransaction(uint(transactionID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("transaction restored successfully"))
+}
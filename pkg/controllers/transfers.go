@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetAllTransfers
+// @Summary Get All Transfers
+// @Security ApiKeyAuth
+// @Tags transfers
+// @Description get list of all transfers between cards
+// @ID get-all-transfers
+// @Produce json
+// @Success 200 {array} models.Transfer
+// @Failure 400 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transfers [get]
+func GetAllTransfers(c *gin.Context) {
+	userID := c.GetUint(userIDCtx)
+	transfers, err := service.GetAllTransfers(userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers})
+}
+
+// GetTransferByID
+// @Summary Get Transfer By ID
+// @Security ApiKeyAuth
+// @Tags transfers
+// @Description get transfer by ID
+// @ID get-transfer-by-id
+// @Produce json
+// @Param id path integer true "id of the transfer"
+// @Success 200 {object} models.Transfer
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transfers/{id} [get]
+func GetTransferByID(c *gin.Context) {
+	transferID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	transfer, err := service.GetTransferByID(userID, uint(transferID))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+// CreateTransfer
+// @Summary Create Transfer
+// @Security ApiKeyAuth
+// @Tags transfers
+// @Description move money from one card to another with optional fee and currency conversion
+// @ID create-transfer
+// @Accept json
+// @Produce json
+// @Param input body models.TransferInput true "new transfer info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transfers [post]
+func CreateTransfer(c *gin.Context) {
+	var input models.TransferInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	transfer := input.Transfer()
+	transfer.UserID = c.GetUint(userIDCtx)
+	if err := service.CreateTransfer(transfer, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, newDefaultResponse("transfer created successfully"))
+}
+
+// CancelTransfer
+// @Summary Cancel Transfer
+// @Security ApiKeyAuth
+// @Tags transfers
+// @Description cancel transfer and return money to the source card
+// @ID cancel-transfer
+// @Produce json
+// @Param id path integer true "id of the transfer"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/transfers/{id}/cancel [post]
+func CancelTransfer(c *gin.Context) {
+	transferID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	userID := c.GetUint(userIDCtx)
+	if err = service.CancelTransfer(uint(transferID), userID, auditMeta(c)); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("transfer cancelled successfully"))
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetTrash
+// @Summary Get Trash
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description get a page of deleted incomes, outcomes, expenses and cards, most recently deleted first by default; records are purged for good after the retention period shown in purge_at
+// @ID get-trash
+// @Produce json
+// @Param entity_type query string false "transaction or card, both by default"
+// @Param page query integer false "page number starting from 1"
+// @Param limit query integer false "page size, 50 by default, 500 at most"
+// @Param order query string false "asc or desc, desc by default"
+// @Success 200 {object} trashList
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/trash [get]
+func GetTrash(c *gin.Context) {
+	params, err := bindListParams(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filter := models.TrashFilter{EntityType: c.Query("entity_type")}
+	items, page, err := service.GetTrash(c.GetUint(userIDCtx), filter, params)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, trashList{Items: items, Pagination: page})
+}
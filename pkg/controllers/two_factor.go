@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"coinkeeper/models"
+	"coinkeeper/pkg/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetTwoFactorStatus
+// @Summary Get Two-Factor Status
+// @Security ApiKeyAuth
+// @Tags two-factor
+// @Description whether two-factor authentication is enabled and required and how many recovery codes are left
+// @ID get-two-factor-status
+// @Produce json
+// @Success 200 {object} models.TwoFactorStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/2fa [get]
+func GetTwoFactorStatus(c *gin.Context) {
+	status, err := service.GetTwoFactorStatus(c.GetUint(userIDCtx))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// SetupTwoFactor
+// @Summary Setup Two-Factor
+// @Security ApiKeyAuth
+// @Tags two-factor
+// @Description create a new TOTP secret and its provisioning URI for a QR code; two-factor authentication stays off until confirmed with /api/me/2fa/enable
+// @ID setup-two-factor
+// @Produce json
+// @Success 200 {object} models.TwoFactorSetup
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/2fa/setup [post]
+func SetupTwoFactor(c *gin.Context) {
+	setup, err := service.SetupTwoFactor(c.GetUint(userIDCtx))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, setup)
+}
+
+// EnableTwoFactor
+// @Summary Enable Two-Factor
+// @Security ApiKeyAuth
+// @Tags two-factor
+// @Description confirm the TOTP secret with a code from the authenticator app and get recovery codes, shown only once; refresh the tokens afterwards if two-factor authentication was required
+// @ID enable-two-factor
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorCodeInput true "TOTP code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/2fa/enable [post]
+func EnableTwoFactor(c *gin.Context) {
+	var input models.TwoFactorCodeInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	codes, err := service.EnableTwoFactor(c.GetUint(userIDCtx), input.Code)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.RecoveryCodes{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor
+// @Summary Disable Two-Factor
+// @Security ApiKeyAuth
+// @Tags two-factor
+// @Description turn two-factor authentication off with the password and a TOTP or recovery code; not allowed when two-factor authentication is required
+// @ID disable-two-factor
+// @Accept json
+// @Produce json
+// @Param input body models.DisableTwoFactorInput true "password and code"
+// @Success 200 {object} defaultResponse
+// @Failure 400 401 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/2fa/disable [post]
+func DisableTwoFactor(c *gin.Context) {
+	var input models.DisableTwoFactorInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := service.DisableTwoFactor(c.GetUint(userIDCtx), input.Password, input.Code); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("two-factor authentication disabled successfully"))
+}
+
+// RegenerateRecoveryCodes
+// @Summary Regenerate Recovery Codes
+// @Security ApiKeyAuth
+// @Tags two-factor
+// @Description replace all recovery codes with new ones after checking a TOTP code
+// @ID regenerate-recovery-codes
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorCodeInput true "TOTP code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/me/2fa/recovery-codes [post]
+func RegenerateRecoveryCodes(c *gin.Context) {
+	var input models.TwoFactorCodeInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+	codes, err := service.RegenerateRecoveryCodes(c.GetUint(userIDCtx), input.Code)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.RecoveryCodes{RecoveryCodes: codes})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"coinkeeper/errs"
 	"coinkeeper/logger"
 	"coinkeeper/models"
 	"coinkeeper/pkg/service"
@@ -9,97 +10,183 @@ import (
 	"strconv"
 )
 
+// GetAllUsers
+// @Summary Get All Users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description get list of all users, admin only
+// @ID admin-get-all-users
+// @Produce json
+// @Success 200 {array} models.User
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users [get]
 func GetAllUsers(c *gin.Context) {
 	logger.Info.Printf("Client with ip: [%s] requested list of users\n", c.ClientIP())
 	users, err := service.GetAllUsers()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"users": users,
 	})
-	logger.Info.Printf("Client with ip: [%s] requested list of users\n", c.ClientIP())
 }
 
+// GetUserByID
+// @Summary Get User By ID
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description get user by ID, admin only
+// @ID admin-get-user-by-id
+// @Produce json
+// @Param id path integer true "id of the user"
+// @Success 200 {object} models.User
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users/{id} [get]
 func GetUserByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		logger.Error.Printf("[controllers.GetUserByID] invalid user_id path parameter: %s\n", c.Param("id"))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
 
 	user, err := service.GetUserByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, user)
 }
 
+// CreateUser
+// @Summary Create User
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description create user with the given role, admin only
+// @ID admin-create-user
+// @Accept json
+// @Produce json
+// @Param input body models.AdminUserInput true "new user info"
+// @Success 201 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users [post]
 func CreateUser(c *gin.Context) {
-	var user models.User
-	if err := c.BindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	var input models.AdminUserInput
+	if err := bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
 
-	err := service.CreateUser(user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	if err := service.CreateUser(input.User()); err != nil {
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "User created successfully",
-	})
+	c.JSON(http.StatusCreated, newDefaultResponse("user created successfully"))
 }
 
+// UpdateUser
+// @Summary Update User
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description change full name, email, base currency or role of the user, admin only; empty fields are kept
+// @ID admin-update-user
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the user"
+// @Param input body models.AdminUserUpdateInput true "user update info"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users/{id} [put]
 func UpdateUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
 
-	var user models.User
-	if err := c.BindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	var input models.AdminUserUpdateInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
 		return
 	}
+	user := input.User()
 	user.ID = uint(id)
-	c.JSON(http.StatusOK, user)
+
+	if err = service.UpdateUser(c.GetUint(userIDCtx), user); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("user updated successfully"))
 }
 
+// SetTwoFactorRequired
+// @Summary Require Two-Factor Authentication
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description oblige the user to enable two-factor authentication or lift the obligation, admin only; applies to tokens issued afterwards
+// @ID admin-set-two-factor-required
+// @Accept json
+// @Produce json
+// @Param id path integer true "id of the user"
+// @Param input body models.TwoFactorRequirementInput true "whether two-factor authentication is required"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "invalid fields"
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users/{id}/2fa [put]
+func SetTwoFactorRequired(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handleError(c, errs.ErrValidationFailed)
+		return
+	}
+
+	var input models.TwoFactorRequirementInput
+	if err = bindJSON(c, &input); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err = service.SetTwoFactorRequired(uint(id), input.Required); err != nil {
+		handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newDefaultResponse("two-factor requirement updated successfully"))
+}
+
+// DeleteUser
+// @Summary Delete User By ID
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description delete user by ID, admin only; admins cannot delete themselves
+// @ID admin-delete-user-by-id
+// @Param id path integer true "id of the user"
+// @Success 200 {object} defaultResponse
+// @Failure 400 403 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure default {object} ErrorResponse
+// @Router /api/admin/users/{id} [delete]
 func DeleteUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, errs.ErrValidationFailed)
 		return
 	}
-	if err = service.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	if err = service.DeleteUser(c.GetUint(userIDCtx), uint(id)); err != nil {
+		handleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User deleted successfully",
-	})
+	c.JSON(http.StatusOK, newDefaultResponse("user deleted successfully"))
 }
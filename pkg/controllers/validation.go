@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// registerValidations настраивает проверку тегов binding у DTO запросов: ошибки называют поля
+// по JSON-именам, суммы models.Money сравниваются правилами gt, gte и required по минорным
+// единицам, правило notblank не пропускает строки из одних пробелов
+func registerValidations() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if money, ok := field.Interface().(models.Money); ok {
+			return money.Minor
+		}
+		return nil
+	}, models.Money{})
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+}
+
+// bindJSON разбирает тело запроса в dto и проверяет правила из его тегов binding
+func bindJSON(c *gin.Context, dto interface{}) error {
+	if err := c.ShouldBindJSON(dto); err != nil {
+		if errors.Is(err, models.ErrInvalidMoney) {
+			return &errs.ValidationError{Err: errs.ErrValidationFailed, Fields: []errs.FieldError{{
+				Field:   moneyField(dto),
+				Code:    errs.FieldInvalid,
+				Message: "must be a decimal amount with at most " + strconv.Itoa(models.MaxCurrencyExponent) + " fractional digits",
+			}}}
+		}
+		return requestError(err)
+	}
+	return nil
+}
+
+// moneyField называет поле суммы в dto: JSON-имя его единственного поля models.Money или amount,
+// если таких полей несколько. Ошибка разбора суммы не сообщает, в каком поле она произошла.
+func moneyField(dto interface{}) string {
+	t := reflect.TypeOf(dto)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	field := "amount"
+	if t.Kind() != reflect.Struct {
+		return field
+	}
+	found := 0
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == reflect.TypeOf(models.Money{}) {
+			field = strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+			found++
+		}
+	}
+	if found != 1 {
+		return "amount"
+	}
+	return field
+}
+
+// requestError превращает ошибку разбора или проверки запроса в ошибку с перечнем полей
+func requestError(err error) error {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]errs.FieldError, 0, len(invalid))
+		for _, fe := range invalid {
+			fields = append(fields, fieldError(fe))
+		}
+		return &errs.ValidationError{Err: errs.ErrInvalidFields, Fields: fields}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &errs.ValidationError{Err: errs.ErrValidationFailed, Fields: []errs.FieldError{{
+			Field:   typeErr.Field,
+			Code:    errs.FieldInvalidType,
+			Message: "must be " + jsonTypeName(typeErr.Type),
+		}}}
+	}
+	return errs.ErrValidationFailed
+}
+
+// fieldError описывает нарушенное правило поля понятным клиенту кодом и сообщением
+func fieldError(fe validator.FieldError) errs.FieldError {
+	field := fe.Namespace()
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+
+	param := fe.Param()
+	measured := fe.Kind() == reflect.String || fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+	switch fe.Tag() {
+	case "required", "notblank":
+		return errs.FieldError{Field: field, Code: errs.FieldRequired, Message: "is required"}
+	case "gt":
+		return errs.FieldError{Field: field, Code: errs.FieldTooSmall, Message: "must be greater than " + param}
+	case "gte", "min":
+		if measured {
+			return errs.FieldError{Field: field, Code: errs.FieldTooSmall, Message: "must have at least " + param + " " + unit(fe.Kind())}
+		}
+		return errs.FieldError{Field: field, Code: errs.FieldTooSmall, Message: "must be at least " + param}
+	case "lte", "max":
+		if measured {
+			return errs.FieldError{Field: field, Code: errs.FieldTooLong, Message: "must have at most " + param + " " + unit(fe.Kind())}
+		}
+		return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "must be at most " + param}
+	case "len":
+		if measured {
+			return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "must have exactly " + param + " " + unit(fe.Kind())}
+		}
+		return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "must be " + param}
+	case "oneof":
+		return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "must be one of: " + strings.ReplaceAll(param, " ", ", ")}
+	case "email":
+		return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "must be a valid email address"}
+	}
+	return errs.FieldError{Field: field, Code: errs.FieldInvalid, Message: "is invalid"}
+}
+
+func unit(kind reflect.Kind) string {
+	if kind == reflect.String {
+		return "characters"
+	}
+	return "items"
+}
+
+// jsonTypeName называет ожидаемый тип поля так, как его видит JSON-клиент
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
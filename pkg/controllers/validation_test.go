@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBindJSONFieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registerValidations()
+
+	tests := []struct {
+		name  string
+		dto   interface{}
+		body  string
+		err   error
+		field string
+	}{
+		{"invalid goal target", &models.GoalInput{}, `{"title": "Vacation", "target": "1.2.3"}`, errs.ErrValidationFailed, "target"},
+		{"invalid budget limit", &models.BudgetInput{}, `{"category_id": 1, "year": 2024, "month": 5, "limit": "ten"}`, errs.ErrValidationFailed, "limit"},
+		{"invalid transfer amount", &models.TransferInput{}, `{"from_card_id": 1, "to_card_id": 2, "amount": "1..5"}`, errs.ErrValidationFailed, "amount"},
+		{"goal without title", &models.GoalInput{}, `{"target": "100"}`, errs.ErrInvalidFields, "title"},
+		{"budget month out of range", &models.BudgetInput{}, `{"category_id": 1, "year": 2024, "month": 13, "limit": "100"}`, errs.ErrInvalidFields, "month"},
+		{"rate currency too long", &models.ExchangeRateInput{}, `{"base_currency": "USDT", "quote_currency": "TJS", "rate": "10.9"}`, errs.ErrInvalidFields, "base_currency"},
+		{"recurring rule without frequency", &models.RecurringRuleInput{}, `{"type": "income", "amount": "100"}`, errs.ErrInvalidFields, "frequency"},
+		{"category merge without target", &models.CategoryMerge{}, `{}`, errs.ErrInvalidFields, "target_id"},
+		{"sign-in without password", &models.SignInInput{}, `{"username": "owner"}`, errs.ErrInvalidFields, "password"},
+		{"two-factor code missing", &models.TwoFactorCodeInput{}, `{}`, errs.ErrInvalidFields, "code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			err := bindJSON(c, tt.dto)
+			var validationErr *errs.ValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, tt.err) {
+				t.Fatalf("bindJSON error = %v, want %v with field errors", err, tt.err)
+			}
+			if len(validationErr.Fields) == 0 || validationErr.Fields[0].Field != tt.field {
+				t.Errorf("bindJSON fields = %+v, want an error for %s", validationErr.Fields, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"io"
+	"time"
+)
+
+// csvWriter пишет один набор данных как обычный CSV, а несколько — как zip-архив
+// с отдельным CSV-файлом на каждый набор
+type csvWriter struct {
+	archive *zip.Writer
+	out     io.Writer
+	csv     *csv.Writer
+	values  []string
+}
+
+func newCSVWriter(w io.Writer, sections []string) *csvWriter {
+	writer := &csvWriter{out: w}
+	if len(sections) > 1 {
+		writer.archive = zip.NewWriter(w)
+	}
+	return writer
+}
+
+func (w *csvWriter) BeginSection(name string, columns []string) error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	out := w.out
+	if w.archive != nil {
+		var err error
+		if out, err = w.archive.CreateHeader(&zip.FileHeader{Name: name + ".csv", Method: zip.Deflate, Modified: time.Now()}); err != nil {
+			return err
+		}
+	} else if w.csv != nil {
+		return errors.New("csv export holds a single section")
+	}
+
+	w.csv = csv.NewWriter(out)
+	return w.csv.Write(columns)
+}
+
+func (w *csvWriter) WriteRow(values ...interface{}) error {
+	w.values = w.values[:0]
+	for _, value := range values {
+		w.values = append(w.values, formatValue(value))
+	}
+	return w.csv.Write(w.values)
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if w.archive != nil {
+		return w.archive.Close()
+	}
+	return nil
+}
+
+func (w *csvWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *csvWriter) ContentType() string {
+	if w.archive != nil {
+		return "application/zip"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (w *csvWriter) Extension() string {
+	if w.archive != nil {
+		return "zip"
+	}
+	return "csv"
+}
@@ -0,0 +1,86 @@
+package exporter
+
+import (
+	"coinkeeper/models"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Number — число в десятичной записи; выгружается как число без промежуточного float
+type Number string
+
+// Writer потоково записывает выгрузку: набор данных открывается BeginSection,
+// после чего его строки пишутся по одной. Значения строки — string, Number, time.Time,
+// bool, uint, int64 или *uint (nil — пустое значение).
+type Writer interface {
+	BeginSection(name string, columns []string) error
+	WriteRow(values ...interface{}) error
+	// Close дописывает служебные части файла; сам io.Writer не закрывается
+	Close() error
+	ContentType() string
+	Extension() string
+}
+
+// NewWriter создаёт Writer формата format. sections — имена наборов данных в порядке записи,
+// они нужны заранее для XLSX и для выбора между одним CSV и zip-архивом из нескольких.
+func NewWriter(format string, w io.Writer, sections []string) (Writer, error) {
+	switch format {
+	case models.ExportFormatCSV:
+		return newCSVWriter(w, sections), nil
+	case models.ExportFormatJSON:
+		return newJSONWriter(w), nil
+	case models.ExportFormatXLSX:
+		return newXLSXWriter(w, sections)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// formatValue возвращает текстовое представление значения для CSV и XLSX
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case Number:
+		return string(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(v)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case *uint:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*v), 10)
+	default:
+		return ""
+	}
+}
+
+func isNumeric(value interface{}) bool {
+	switch v := value.(type) {
+	case Number, uint, uint64, int64, int:
+		return true
+	case *uint:
+		return v != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonWriter пишет объект {"<набор>": [{"<колонка>": значение, ...}, ...], ...}
+// построчно, не собирая выгрузку в памяти
+type jsonWriter struct {
+	out      *bufio.Writer
+	columns  [][]byte
+	sections int
+	rows     int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{out: bufio.NewWriter(w)}
+}
+
+func (w *jsonWriter) BeginSection(name string, columns []string) error {
+	w.columns = w.columns[:0]
+	for _, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		w.columns = append(w.columns, key)
+	}
+
+	if w.sections == 0 {
+		w.out.WriteByte('{')
+	} else {
+		w.out.WriteString("],")
+	}
+	w.sections++
+	w.rows = 0
+
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+	w.out.Write(key)
+	_, err = w.out.WriteString(":[")
+	return err
+}
+
+func (w *jsonWriter) WriteRow(values ...interface{}) error {
+	if w.rows > 0 {
+		w.out.WriteByte(',')
+	}
+	w.rows++
+
+	w.out.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			w.out.WriteByte(',')
+		}
+		w.out.Write(w.columns[i])
+		w.out.WriteByte(':')
+
+		encoded, err := jsonValue(value)
+		if err != nil {
+			return err
+		}
+		w.out.Write(encoded)
+	}
+	_, err := w.out.WriteString("}\n")
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	if w.sections == 0 {
+		w.out.WriteString("{}")
+	} else {
+		w.out.WriteString("]}")
+	}
+	return w.out.Flush()
+}
+
+func jsonValue(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case Number:
+		return []byte(v), nil
+	case time.Time:
+		if v.IsZero() {
+			return []byte("null"), nil
+		}
+		return json.Marshal(v.Format(time.RFC3339))
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func (w *jsonWriter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (w *jsonWriter) Extension() string {
+	return "json"
+}
@@ -0,0 +1,169 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxWriter пишет минимальную книгу Office Open XML: по листу на набор данных.
+// Строки листа пишутся прямо в zip-архив, строки текста — inline, без общей таблицы строк,
+// поэтому выгрузка не держится в памяти целиком.
+type xlsxWriter struct {
+	archive  *zip.Writer
+	sheet    *bufio.Writer
+	sections []string
+	current  int
+	row      int
+}
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+	`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+func newXLSXWriter(w io.Writer, sections []string) (*xlsxWriter, error) {
+	writer := &xlsxWriter{archive: zip.NewWriter(w), sections: sections, current: -1}
+
+	var contentTypes, workbook, relationships string
+	for i, name := range sections {
+		contentTypes += fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		workbook += fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), i+1, i+1)
+		relationships += fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			contentTypes + `</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + workbook + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			relationships + `</Relationships>`},
+	}
+	for _, file := range files {
+		part, err := writer.archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(part, file.body); err != nil {
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+func (w *xlsxWriter) BeginSection(name string, columns []string) error {
+	if w.current+1 >= len(w.sections) || w.sections[w.current+1] != name {
+		return fmt.Errorf("unexpected xlsx section %q", name)
+	}
+	if err := w.nextSheet(); err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return w.WriteRow(values...)
+}
+
+func (w *xlsxWriter) WriteRow(values ...interface{}) error {
+	if w.sheet == nil {
+		return errors.New("xlsx section is not started")
+	}
+	w.row++
+
+	w.sheet.WriteString(`<row r="` + strconv.Itoa(w.row) + `">`)
+	for i, value := range values {
+		text := formatValue(value)
+		if text == "" {
+			continue
+		}
+		ref := columnName(i) + strconv.Itoa(w.row)
+		if isNumeric(value) {
+			w.sheet.WriteString(`<c r="` + ref + `"><v>` + text + `</v></c>`)
+		} else {
+			w.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(text) + `</t></is></c>`)
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close закрывает последний лист и дописывает пустые листы для наборов, до которых не дошла запись
+func (w *xlsxWriter) Close() error {
+	for w.current+1 < len(w.sections) {
+		if err := w.nextSheet(); err != nil {
+			return err
+		}
+	}
+	if err := w.closeSheet(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+func (w *xlsxWriter) nextSheet() error {
+	if err := w.closeSheet(); err != nil {
+		return err
+	}
+	w.current++
+	w.row = 0
+
+	part, err := w.archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", w.current+1))
+	if err != nil {
+		return err
+	}
+	w.sheet = bufio.NewWriter(part)
+	_, err = w.sheet.WriteString(xlsxSheetHeader)
+	return err
+}
+
+func (w *xlsxWriter) closeSheet() error {
+	if w.sheet == nil {
+		return nil
+	}
+	w.sheet.WriteString(xlsxSheetFooter)
+	err := w.sheet.Flush()
+	w.sheet = nil
+	return err
+}
+
+func (w *xlsxWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (w *xlsxWriter) Extension() string {
+	return "xlsx"
+}
+
+// columnName переводит номер колонки, начиная с 0, в буквенное обозначение: 0 — A, 26 — AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
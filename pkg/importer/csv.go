@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"coinkeeper/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidMapping = errors.New("invalid csv mapping")
+
+// csvColumns — индексы колонок, вычисленные по CSVMapping; -1 означает, что колонка не задана
+type csvColumns struct {
+	date, amount, debit, credit, description, category int
+}
+
+func parseCSV(r io.Reader, options Options) ([]models.ImportRow, error) {
+	mapping := options.Mapping
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(mapping.Delimiter)
+		if size != len(mapping.Delimiter) {
+			return nil, fmt.Errorf("%w: delimiter must be a single character", ErrInvalidMapping)
+		}
+		reader.Comma = delimiter
+	}
+
+	var header []string
+	if mapping.HasHeader {
+		var err error
+		if header, err = reader.Read(); err != nil {
+			return nil, err
+		}
+	}
+
+	columns, err := resolveColumns(mapping, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, models.ImportRow{Line: parseErr.StartLine, Error: err.Error()})
+				continue
+			}
+			return nil, err
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(record, line, columns, mapping, options.Currency))
+	}
+	return rows, nil
+}
+
+func csvRow(record []string, line int, columns csvColumns, mapping models.CSVMapping, currency string) models.ImportRow {
+	row := models.ImportRow{Line: line, Description: strings.TrimSpace(field(record, columns.description))}
+
+	date, err := parseDate(field(record, columns.date), mapping.DateFormat)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Date = date
+
+	if columns.amount >= 0 {
+		row.Amount, err = parseAmount(field(record, columns.amount), mapping.DecimalSeparator, currency)
+	} else {
+		row.Amount, err = debitCreditAmount(field(record, columns.debit), field(record, columns.credit), mapping.DecimalSeparator, currency)
+	}
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+
+	if category := strings.TrimSpace(field(record, columns.category)); category != "" {
+		id, err := strconv.ParseUint(category, 10, 64)
+		if err != nil {
+			row.Error = fmt.Sprintf("invalid category id %q", category)
+			return row
+		}
+		categoryID := uint(id)
+		row.CategoryID = &categoryID
+	}
+	return row
+}
+
+// debitCreditAmount собирает сумму со знаком из раздельных колонок списания и зачисления
+func debitCreditAmount(debit, credit, decimalSeparator, currency string) (models.Money, error) {
+	debit, credit = strings.TrimSpace(debit), strings.TrimSpace(credit)
+	switch {
+	case debit != "" && credit != "":
+		return models.Money{}, errors.New("both debit and credit are filled")
+	case debit != "":
+		amount, err := parseAmount(debit, decimalSeparator, currency)
+		if err != nil {
+			return models.Money{}, err
+		}
+		if !amount.IsNegative() {
+			amount = amount.Neg()
+		}
+		return amount, nil
+	case credit != "":
+		return parseAmount(credit, decimalSeparator, currency)
+	default:
+		return models.Money{}, errors.New("amount is empty")
+	}
+}
+
+func resolveColumns(mapping models.CSVMapping, header []string) (csvColumns, error) {
+	var columns csvColumns
+	var err error
+	if columns.date, err = columnIndex(mapping.Date, header); err != nil {
+		return columns, err
+	}
+	if columns.amount, err = columnIndex(mapping.Amount, header); err != nil {
+		return columns, err
+	}
+	if columns.debit, err = columnIndex(mapping.Debit, header); err != nil {
+		return columns, err
+	}
+	if columns.credit, err = columnIndex(mapping.Credit, header); err != nil {
+		return columns, err
+	}
+	if columns.description, err = columnIndex(mapping.Description, header); err != nil {
+		return columns, err
+	}
+	if columns.category, err = columnIndex(mapping.Category, header); err != nil {
+		return columns, err
+	}
+
+	if columns.date < 0 {
+		return columns, fmt.Errorf("%w: date column is required", ErrInvalidMapping)
+	}
+	if columns.amount < 0 && columns.debit < 0 && columns.credit < 0 {
+		return columns, fmt.Errorf("%w: amount or debit/credit columns are required", ErrInvalidMapping)
+	}
+	return columns, nil
+}
+
+// columnIndex ищет колонку по названию из заголовка (без учёта регистра) или по номеру, начиная с 1
+func columnIndex(ref string, header []string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), ref) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n > 0 {
+		return n - 1, nil
+	}
+	return -1, fmt.Errorf("%w: column %q not found", ErrInvalidMapping, ref)
+}
+
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return record[index]
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
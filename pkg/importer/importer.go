@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"coinkeeper/models"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+// Options — параметры разбора выписки
+type Options struct {
+	Currency string
+	Mapping  models.CSVMapping
+}
+
+// Parse разбирает выписку в формате format (csv, ofx или qif).
+// Ошибка возвращается только если файл не читается целиком; ошибки отдельных строк
+// записываются в ImportRow.Error.
+func Parse(format string, r io.Reader, options Options) ([]models.ImportRow, error) {
+	switch strings.ToLower(format) {
+	case models.ImportFormatCSV:
+		return parseCSV(r, options)
+	case models.ImportFormatOFX:
+		return parseOFX(r, options)
+	case models.ImportFormatQIF:
+		return parseQIF(r, options)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// DetectFormat определяет формат по расширению файла
+func DetectFormat(filename string) string {
+	name := strings.ToLower(filename)
+	for _, format := range []string{models.ImportFormatCSV, models.ImportFormatOFX, models.ImportFormatQIF} {
+		if strings.HasSuffix(name, "."+format) {
+			return format
+		}
+	}
+	if strings.HasSuffix(name, ".qfx") {
+		return models.ImportFormatOFX
+	}
+	return ""
+}
+
+// parseAmount разбирает сумму банковской выписки: убирает пробелы-разделители разрядов,
+// приводит десятичный разделитель к точке и понимает скобки как минус
+func parseAmount(value, decimalSeparator, currency string) (models.Money, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	value = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(value)
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+	if decimalSeparator == "," {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := models.ParseMoney(value, currency)
+	if err != nil {
+		return models.Money{}, err
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+// parseDate пробует формат layout, а если он не задан — распространённые форматы выписок
+func parseDate(value, layout string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	layouts := []string{layout}
+	if layout == "" {
+		layouts = []string{"2006-01-02", "02.01.2006", "01/02/2006", "02/01/2006", "2006/01/02", "01/02/06", "01/02'06", "1/2'06", "1/2/2006"}
+	}
+
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse date %q", value)
+}
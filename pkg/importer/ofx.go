@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"bytes"
+	"coinkeeper/models"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// parseOFX разбирает OFX 1.x (SGML, без закрывающих тегов у значений) и OFX 2.x (XML).
+// Из выписки берутся операции STMTTRN: DTPOSTED, TRNAMT, NAME и MEMO; валюта — из CURDEF,
+// если она не задана явно.
+func parseOFX(r io.Reader, options Options) ([]models.ImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := options.Currency
+	var rows []models.ImportRow
+	var current map[string]string
+	line, start := 1, 0
+
+	// Каждый фрагмент имеет вид "TAG>значение" или "/TAG>"
+	for i, chunk := range bytes.Split(data, []byte("<")) {
+		tagLine := line
+		line += bytes.Count(chunk, []byte("\n"))
+
+		tag, value, ok := strings.Cut(string(chunk), ">")
+		if i == 0 || !ok {
+			continue
+		}
+		tag = strings.ToUpper(strings.TrimSpace(tag))
+		value = strings.TrimSpace(value)
+
+		switch {
+		case tag == "STMTTRN":
+			current = map[string]string{}
+			start = tagLine
+		case tag == "/STMTTRN":
+			if current != nil {
+				rows = append(rows, ofxRow(current, start, currency))
+			}
+			current = nil
+		case tag == "CURDEF" && currency == "":
+			currency = value
+		case current != nil && !strings.HasPrefix(tag, "/"):
+			current[tag] = value
+		}
+	}
+	return rows, nil
+}
+
+func ofxRow(fields map[string]string, line int, currency string) models.ImportRow {
+	row := models.ImportRow{Line: line, Description: ofxDescription(fields)}
+
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Date = date
+
+	if row.Amount, err = parseAmount(fields["TRNAMT"], ".", currency); err != nil {
+		row.Error = err.Error()
+	}
+	return row
+}
+
+func ofxDescription(fields map[string]string) string {
+	name, memo := fields["NAME"], fields["MEMO"]
+	switch {
+	case name == "":
+		return memo
+	case memo == "" || memo == name:
+		return name
+	default:
+		return name + " — " + memo
+	}
+}
+
+// parseOFXDate разбирает дату вида YYYYMMDD[HHMMSS[.XXX]][gmt offset:tz name]
+func parseOFXDate(value string) (time.Time, error) {
+	value, tz, hasTZ := strings.Cut(value, "[")
+	if i := strings.Index(value, "."); i >= 0 {
+		value = value[:i]
+	}
+
+	location := time.Local
+	if hasTZ {
+		offset, _, _ := strings.Cut(strings.TrimSuffix(tz, "]"), ":")
+		var hours float64
+		if _, err := fmt.Sscanf(offset, "%g", &hours); err == nil {
+			location = time.FixedZone("", int(hours*3600))
+		}
+	}
+
+	for _, layout := range []string{"20060102150405", "200601021504", "20060102"} {
+		if len(value) == len(layout) {
+			if t, err := time.ParseInLocation(layout, value, location); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse date %q", value)
+}
@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"bufio"
+	"coinkeeper/models"
+	"io"
+	"strings"
+)
+
+// parseQIF разбирает банковскую выписку QIF: записи из строк D (дата), T или U (сумма),
+// P (получатель) и M (комментарий), разделённые строкой "^". Заголовки "!Type:..." пропускаются.
+func parseQIF(r io.Reader, options Options) ([]models.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+
+	var rows []models.ImportRow
+	fields := map[byte]string{}
+	line, start := 0, 0
+
+	flush := func() {
+		if len(fields) > 0 {
+			rows = append(rows, qifRow(fields, start, options))
+		}
+		fields = map[byte]string{}
+	}
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "!") {
+			continue
+		}
+		if text == "^" {
+			flush()
+			continue
+		}
+		if len(fields) == 0 {
+			start = line
+		}
+		code := text[0]
+		if _, seen := fields[code]; !seen {
+			fields[code] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return rows, nil
+}
+
+func qifRow(fields map[byte]string, line int, options Options) models.ImportRow {
+	row := models.ImportRow{Line: line, Description: fields['P']}
+	if memo := fields['M']; memo != "" && memo != row.Description {
+		if row.Description == "" {
+			row.Description = memo
+		} else {
+			row.Description += " — " + memo
+		}
+	}
+
+	date, err := parseDate(fields['D'], options.Mapping.DateFormat)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Date = date
+
+	amount, ok := fields['T']
+	if !ok {
+		amount = fields['U']
+	}
+	if row.Amount, err = parseAmount(amount, options.Mapping.DecimalSeparator, options.Currency); err != nil {
+		row.Error = err.Error()
+	}
+	return row
+}
@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/pkg/service"
+	"time"
+)
+
+func runAccountPurge(now time.Time) error {
+	purged, err := service.PurgeDeletedAccounts(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		logger.Info.Printf("[jobs.runAccountPurge] purged %d deleted accounts\n", purged)
+	}
+	return nil
+}
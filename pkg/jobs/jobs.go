@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/logger"
+	"context"
+	"sync"
+	"time"
+)
+
+var wg sync.WaitGroup
+
+// Start запускает фоновые задачи приложения. Задачи останавливаются при отмене ctx.
+func Start(ctx context.Context) {
+	params := configs.AppSettings.JobParams
+
+	start(ctx, "recurring", seconds(params.RecurringIntervalSeconds), runRecurring)
+	start(ctx, "token-cleanup", seconds(params.TokenCleanupIntervalSeconds), runTokenCleanup)
+	start(ctx, "login-cleanup", seconds(params.LoginCleanupIntervalSeconds), runLoginCleanup)
+	start(ctx, "account-purge", seconds(params.AccountPurgeIntervalSeconds), runAccountPurge)
+	start(ctx, "trash-purge", seconds(params.TrashPurgeIntervalSeconds), runTrashPurge)
+}
+
+// Wait дожидается завершения всех задач после отмены контекста
+func Wait() {
+	wg.Wait()
+}
+
+func start(ctx context.Context, name string, interval time.Duration, job func(now time.Time) error) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		run(ctx, name, interval, job)
+	}()
+}
+
+// run вызывает job сразу после старта, а затем каждые interval, пока не отменён ctx
+func run(ctx context.Context, name string, interval time.Duration, job func(now time.Time) error) {
+	logger.Info.Printf("[jobs.run] job %s started with interval %s\n", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(time.Now()); err != nil {
+			logger.Error.Printf("[jobs.run] job %s failed. Error is: %s\n", name, err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info.Printf("[jobs.run] job %s stopped\n", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// seconds переводит интервал из настроек в time.Duration, по умолчанию — минута
+func seconds(value int) time.Duration {
+	if value <= 0 {
+		return time.Minute
+	}
+	return time.Duration(value) * time.Second
+}
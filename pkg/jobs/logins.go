@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/pkg/service"
+	"time"
+)
+
+func runLoginCleanup(now time.Time) error {
+	purged, err := service.PurgeLoginRecords(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		logger.Info.Printf("[jobs.runLoginCleanup] purged %d login records\n", purged)
+	}
+	return nil
+}
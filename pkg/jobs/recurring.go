@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/pkg/service"
+	"time"
+)
+
+func runRecurring(now time.Time) error {
+	created, err := service.RunDueRecurringRules(now)
+	if err != nil {
+		return err
+	}
+	if created > 0 {
+		logger.Info.Printf("[jobs.runRecurring] created %d recurring transactions\n", created)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/pkg/service"
+	"time"
+)
+
+func runTokenCleanup(now time.Time) error {
+	purged, err := service.PurgeExpiredTokens(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		logger.Info.Printf("[jobs.runTokenCleanup] purged %d expired tokens\n", purged)
+	}
+	return nil
+}
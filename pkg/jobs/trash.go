@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/pkg/service"
+	"time"
+)
+
+func runTrashPurge(now time.Time) error {
+	transactions, cards, err := service.PurgeTrash(now)
+	if err != nil {
+		return err
+	}
+	if transactions > 0 || cards > 0 {
+		logger.Info.Printf("[jobs.runTrashPurge] purged %d transactions and %d cards from trash\n", transactions, cards)
+	}
+	return nil
+}
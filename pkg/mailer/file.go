@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileSender сохраняет каждое письмо в отдельный .eml файл каталога dir — для локальной отладки
+type fileSender struct {
+	dir  string
+	from string
+}
+
+func newFileSender(params models.MailParams) (Sender, error) {
+	dir := params.Directory
+	if dir == "" {
+		dir = "mail"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return fileSender{dir: dir, from: params.From}, nil
+}
+
+func (s fileSender) Send(msg Message) error {
+	now := time.Now()
+	suffix, err := utils.RandomID(4)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405"), suffix))
+	return os.WriteFile(name, formatMessage(s.from, msg, now), 0600)
+}
+
+// logSender пишет письма в информационный лог — для локальной отладки без почтового сервера
+type logSender struct{}
+
+func (logSender) Send(msg Message) error {
+	logger.Info.Printf("[mailer.logSender] mail to %s, subject %q:\n%s\n", msg.To, msg.Subject, msg.Body)
+	return nil
+}
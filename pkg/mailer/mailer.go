@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"bytes"
+	"coinkeeper/configs"
+	"coinkeeper/models"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Драйверы отправки писем из mail_params.driver
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+var (
+	ErrUnknownDriver  = errors.New("unknown mail driver")
+	ErrInvalidMessage = errors.New("invalid mail message")
+)
+
+// Message — письмо одному получателю в виде простого текста
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender отправляет письма
+type Sender interface {
+	Send(msg Message) error
+}
+
+// sender — отправитель, созданный Init; до вызова Init письма пишутся в лог
+var sender Sender = logSender{}
+
+// Init создаёт отправителя по настройкам mail_params, драйвер по умолчанию — log
+func Init() error {
+	s, err := NewSender(configs.AppSettings.MailParams)
+	if err != nil {
+		return err
+	}
+	sender = s
+	return nil
+}
+
+// NewSender создаёт отправителя драйвера params.Driver
+func NewSender(params models.MailParams) (Sender, error) {
+	switch params.Driver {
+	case DriverSMTP:
+		return newSMTPSender(params)
+	case DriverFile:
+		return newFileSender(params)
+	case DriverLog, "":
+		return logSender{}, nil
+	default:
+		return nil, ErrUnknownDriver
+	}
+}
+
+// Send отправляет письмо отправителем из настроек
+func Send(msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+	return sender.Send(msg)
+}
+
+// formatMessage собирает письмо в формате RFC 5322: заголовки и тело с переводами строк CRLF
+func formatMessage(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"coinkeeper/models"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"time"
+)
+
+// smtpSender отправляет письма через SMTP-сервер. Если сервер поддерживает STARTTLS,
+// соединение шифруется; авторизация PLAIN выполняется только при заданном smtp_username.
+type smtpSender struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func newSMTPSender(params models.MailParams) (Sender, error) {
+	if params.SMTPHost == "" || params.From == "" {
+		return nil, errors.New("smtp_host and from are required for smtp mail driver")
+	}
+	port := params.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+	return smtpSender{
+		addr:     net.JoinHostPort(params.SMTPHost, port),
+		host:     params.SMTPHost,
+		from:     params.From,
+		username: params.SMTPUsername,
+		password: os.Getenv("SMTP_PASSWORD"),
+	}, nil
+}
+
+func (s smtpSender) Send(msg Message) error {
+	// В конверте SMTP нужен голый адрес, а в заголовке From может быть и имя
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	return smtp.SendMail(s.addr, auth, from.Address, []string{msg.To}, formatMessage(s.from, msg, time.Now()))
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"bytes"
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"encoding/json"
+	"gorm.io/gorm"
+)
+
+// writeAudit добавляет запись в журнал изменений внутри транзакции tx, в которой меняется сама запись.
+// before и after — снимки записи до и после изменения, nil означает отсутствие снимка.
+func writeAudit(tx *gorm.DB, userID uint, action, entityType string, entityID uint, before, after interface{}, meta models.AuditMeta) error {
+	entry := models.AuditEntry{
+		UserID:     userID,
+		ActorID:    meta.ActorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		IPAddress:  meta.IPAddress,
+		RequestID:  meta.RequestID,
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+	if before != nil && after != nil {
+		if entry.Changes, err = auditChanges(entry.Before, entry.After); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Create(&entry).Error; err != nil {
+		logger.Error.Println("[repository.writeAudit] cannot write audit entry. Error is:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// auditChanges сравнивает поля двух JSON-объектов и возвращает различающиеся
+func auditChanges(before, after models.AuditData) (models.AuditData, error) {
+	var old, updated map[string]json.RawMessage
+	if err := json.Unmarshal(before, &old); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &updated); err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.AuditChange{}
+	for field, value := range updated {
+		if !bytes.Equal(old[field], value) {
+			changes[field] = models.AuditChange{Before: nullIfEmpty(old[field]), After: value}
+		}
+	}
+	for field, value := range old {
+		if _, ok := updated[field]; !ok {
+			changes[field] = models.AuditChange{Before: value, After: json.RawMessage("null")}
+		}
+	}
+	return json.Marshal(changes)
+}
+
+func nullIfEmpty(value json.RawMessage) json.RawMessage {
+	if len(value) == 0 {
+		return json.RawMessage("null")
+	}
+	return value
+}
+
+// auditSortColumns — колонки сортировки журнала изменений: по времени изменения
+var auditSortColumns = map[string]string{
+	models.SortByDate: "created_at",
+}
+
+// auditFilter добавляет к выборке записей журнала пользователя условия filter
+func auditFilter(query *gorm.DB, userID uint, filter models.AuditFilter) *gorm.DB {
+	query = query.Where("user_id = ?", userID)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// GetAuditEntriesPage возвращает страницу журнала изменений пользователя и общее число подходящих записей
+func GetAuditEntriesPage(userID uint, filter models.AuditFilter, params models.ListParams) (entries []models.AuditEntry, total int64, err error) {
+	err = auditFilter(db.GetDBConn().Model(&models.AuditEntry{}), userID, filter).Count(&total).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAuditEntriesPage] cannot count audit entries. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+
+	err = listPage(auditFilter(db.GetDBConn().Model(&models.AuditEntry{}), userID, filter), params, auditSortColumns, "id").
+		Find(&entries).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAuditEntriesPage] cannot get audit entries. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+	return entries, total, nil
+}
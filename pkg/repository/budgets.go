@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"time"
+)
+
+func GetAllBudgets(userID uint, year, month int) ([]models.Budget, error) {
+	var budgets []models.Budget
+
+	query := db.GetDBConn().Where("user_id = ?", userID)
+	if year > 0 {
+		query = query.Where("year = ?", year)
+	}
+	if month > 0 {
+		query = query.Where("month = ?", month)
+	}
+
+	err := query.Order("year DESC, month DESC, category_id").Find(&budgets).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllBudgets] cannot get all budgets. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return budgets, nil
+}
+
+func GetBudgetByID(userID, budgetID uint) (budget models.Budget, err error) {
+	err = db.GetDBConn().Where("id = ? AND user_id = ?", budgetID, userID).First(&budget).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetBudgetByID] cannot get budget by id. Error is:", err.Error())
+		return models.Budget{}, translateError(err)
+	}
+	return budget, nil
+}
+
+// GetBudgetOwnerID возвращает владельца бюджета
+func GetBudgetOwnerID(budgetID uint) (ownerID uint, err error) {
+	var budget models.Budget
+	if err = db.GetDBConn().Select("user_id").Where("id = ?", budgetID).First(&budget).Error; err != nil {
+		logger.Error.Println("[repository.GetBudgetOwnerID] cannot find budget. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return budget.UserID, nil
+}
+
+// GetBudgetByPeriod ищет бюджет пользователя по категории за месяц
+func GetBudgetByPeriod(userID, categoryID uint, year, month int) (budget models.Budget, err error) {
+	err = db.GetDBConn().
+		Where("user_id = ? AND category_id = ? AND year = ? AND month = ?", userID, categoryID, year, month).
+		First(&budget).Error
+	if err != nil {
+		return models.Budget{}, translateError(err)
+	}
+	return budget, nil
+}
+
+func CreateBudget(budget models.Budget) error {
+	if err := db.GetDBConn().Create(&budget).Error; err != nil {
+		logger.Error.Println("[repository.CreateBudget] cannot create budget. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func UpdateBudget(budget models.Budget) error {
+	err := db.GetDBConn().Model(&models.Budget{}).
+		Where("id = ? AND user_id = ?", budget.ID, budget.UserID).
+		Updates(map[string]interface{}{
+			"category_id":    budget.CategoryID,
+			"year":           budget.Year,
+			"month":          budget.Month,
+			"limit_minor":    budget.Limit.Minor,
+			"limit_currency": budget.Limit.Currency,
+		}).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateBudget] cannot update budget. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func DeleteBudget(budgetID, userID uint) error {
+	err := db.GetDBConn().Where("id = ? AND user_id = ?", budgetID, userID).Delete(&models.Budget{}).Error
+	if err != nil {
+		logger.Error.Println("[repository.DeleteBudget] cannot delete budget. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// GetCategorySpending суммирует расходы пользователя по категории за месяц отдельно для каждой валюты.
+// Месяц операции определяется в зоне timeZone, как и периоды отчётов; собственная зона операции
+// влияет только на показ её даты.
+func GetCategorySpending(userID, categoryID uint, year, month int, timeZone string) ([]models.Money, error) {
+	// Границы с запасом в сутки покрывают смещение любой зоны и оставляют выборку по индексу даты
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	var spending []models.Money
+	err := db.GetDBConn().Model(&models.Transaction{}).Scopes(notDeleted("transactions")).
+		Select("amount_currency AS currency, SUM(amount_minor) AS minor").
+		Where("user_id = ? AND type = ? AND category_id = ? AND date >= ? AND date < ?",
+			userID, models.TransactionTypeExpense, categoryID, from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)).
+		Where("date_trunc('month', date AT TIME ZONE ?) = make_date(?, ?, 1)", timeZone, year, month).
+		Group("amount_currency").
+		Scan(&spending).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetCategorySpending] cannot sum category spending. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return spending, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"testing"
+	"time"
+)
+
+// Бюджет и отчёт относят операцию к одному месяцу — в зоне профиля, а не в зоне самой операции
+func TestBudgetAndReportShareMonth(t *testing.T) {
+	testdb.Open(t)
+	user := testdb.CreateUser(t, "owner")
+	card := testdb.CreateCard(t, user.ID, models.NewMoney(100000, "TJS"))
+	category := models.Category{Title: "Groceries", Type: models.CategoryTypeOutcome, UserID: &user.ID}
+	if err := db.GetDBConn().Create(&category).Error; err != nil {
+		t.Fatalf("cannot create category: %v", err)
+	}
+
+	// 31 марта 22:00 UTC — уже 1 апреля в Душанбе (UTC+5)
+	transaction := models.Transaction{
+		Type:        models.TransactionTypeExpense,
+		Amount:      models.NewMoney(1000, "TJS"),
+		Description: "groceries",
+		CategoryID:  &category.ID,
+		CardID:      &card.ID,
+		Date:        time.Date(2024, time.March, 31, 22, 0, 0, 0, time.UTC),
+		TimeZone:    "Asia/Dushanbe",
+		UserID:      user.ID,
+	}
+	if err := CreateTransaction(&transaction, models.AuditMeta{ActorID: user.ID}); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	spending, err := GetCategorySpending(user.ID, category.ID, 2024, 3, "UTC")
+	if err != nil {
+		t.Fatalf("GetCategorySpending: %v", err)
+	}
+	if len(spending) != 1 || spending[0].Minor != 1000 {
+		t.Errorf("March budget spending = %v, want 1000 minor units", spending)
+	}
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	rows, err := GetReportByPeriod(user.ID, from, from.AddDate(0, 2, 0), "month", "UTC")
+	if err != nil {
+		t.Fatalf("GetReportByPeriod: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].Period.Equal(from) {
+		t.Errorf("report periods = %+v, want only March", rows)
+	}
+}
@@ -2,12 +2,22 @@ package repository
 
 import (
 	"coinkeeper/db"
+	"coinkeeper/errs"
 	"coinkeeper/logger"
 	"coinkeeper/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
-func CreateCard(card models.Card) error {
-	err := db.GetDBConn().Create(&card).Error
+func CreateCard(card models.Card, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&card).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, card.UserID, models.AuditActionCreate, models.AuditEntityCard, card.ID, nil, card, meta)
+	})
 	if err != nil {
 		logger.Error.Println("[repository.CreateCard] cannot create card. Error is:", err.Error())
 		return translateError(err)
@@ -15,48 +25,183 @@ func CreateCard(card models.Card) error {
 	return nil
 }
 
-func UpdateCardBalance(cardID uint, amount float32) error {
+// GetCardOwnerID возвращает владельца карты, в том числе удалённой
+func GetCardOwnerID(cardID uint) (ownerID uint, err error) {
 	var card models.Card
+	if err = db.GetDBConn().Select("user_id").Where("id = ?", cardID).First(&card).Error; err != nil {
+		logger.Error.Println("[repository.GetCardOwnerID] cannot find card. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return card.UserID, nil
+}
 
-	// Находим карту
-	if err := db.GetDBConn().First(&card, cardID).Error; err != nil {
+func UpdateCardBalance(cardID, userID uint, amount models.Money, meta models.AuditMeta) error {
+	var card models.Card
+	if err := db.GetDBConn().Scopes(notDeleted("cards")).Where("id = ? AND user_id = ?", cardID, userID).First(&card).Error; err != nil {
 		logger.Error.Println("[repository.UpdateCardBalance] cannot find card. Error is:", err.Error())
 		return translateError(err)
 	}
-
-	// Обновляем баланс
-	card.Balance += amount
-	if err := db.GetDBConn().Save(&card).Error; err != nil {
+	if err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		if err := adjustCardBalance(tx, card.ID, card.UserID, amount); err != nil {
+			return err
+		}
+		var after models.Card
+		if err := tx.Where("id = ?", card.ID).First(&after).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, card.UserID, models.AuditActionUpdate, models.AuditEntityCard, card.ID, card, after, meta)
+	}); err != nil {
 		logger.Error.Println("[repository.UpdateCardBalance] cannot update card balance. Error is:", err.Error())
 		return translateError(err)
 	}
 	return nil
 }
 
+// adjustCardBalance атомарно изменяет баланс карты пользователя на delta внутри транзакции tx.
+// Валюта delta должна совпадать с валютой карты.
+func adjustCardBalance(tx *gorm.DB, cardID, userID uint, delta models.Money) error {
+	var card models.Card
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("cards")).
+		Where("id = ? AND user_id = ?", cardID, userID).
+		First(&card).Error
+	if err != nil {
+		logger.Error.Printf("[repository.adjustCardBalance] cannot find card %d of user %d. Error is: %s\n", cardID, userID, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) && cardInTrash(tx, cardID, userID) {
+			return errs.ErrCardDeleted
+		}
+		return translateError(err)
+	}
+
+	if delta.Currency != card.Balance.Currency {
+		logger.Error.Printf("[repository.adjustCardBalance] currency %s does not match card %d currency %s\n", delta.Currency, cardID, card.Balance.Currency)
+		return errs.ErrCurrencyMismatch
+	}
+
+	err = tx.Model(&models.Card{}).
+		Where("id = ?", card.ID).
+		Update("balance_minor", gorm.Expr("balance_minor + ?", delta.Minor)).Error
+	if err != nil {
+		logger.Error.Println("[repository.adjustCardBalance] cannot adjust card balance. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// cardInTrash сообщает, что карта пользователя есть, но удалена
+func cardInTrash(tx *gorm.DB, cardID, userID uint) bool {
+	var count int64
+	err := tx.Model(&models.Card{}).Where("id = ? AND user_id = ? AND is_deleted = true", cardID, userID).Count(&count).Error
+	if err != nil {
+		logger.Error.Println("[repository.cardInTrash] cannot count deleted cards. Error is:", err.Error())
+		return false
+	}
+	return count > 0
+}
+
+// adjustAuditedCardBalance изменяет баланс карты как adjustCardBalance и записывает в журнал
+// снимки карты до и после изменения
+func adjustAuditedCardBalance(tx *gorm.DB, cardID, userID uint, delta models.Money, meta models.AuditMeta) error {
+	var before models.Card
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", cardID).First(&before).Error; err != nil {
+		logger.Error.Printf("[repository.adjustAuditedCardBalance] cannot find card %d. Error is: %s\n", cardID, err.Error())
+		return translateError(err)
+	}
+	if err := adjustCardBalance(tx, cardID, userID, delta); err != nil {
+		return err
+	}
+	var after models.Card
+	if err := tx.Where("id = ?", cardID).First(&after).Error; err != nil {
+		return err
+	}
+	return writeAudit(tx, userID, models.AuditActionUpdate, models.AuditEntityCard, cardID, before, after, meta)
+}
+
 func GetAllCards(userID uint) ([]models.Card, error) {
 	var cards []models.Card
-	if err := db.GetDBConn().Where("user_id = ?", userID).Find(&cards).Error; err != nil {
+	if err := db.GetDBConn().Scopes(notDeleted("cards")).Where("user_id = ?", userID).Find(&cards).Error; err != nil {
 		logger.Error.Println("[repository.GetAllCards] cannot find card. Error is:", err.Error())
-		return nil, err
+		return nil, translateError(err)
 	}
 	return cards, nil
 }
 
+// cardSortColumns — колонки сортировки списка карт: по дате создания и по балансу
+var cardSortColumns = map[string]string{
+	models.SortByDate:   "created_at",
+	models.SortByAmount: "balance_minor",
+}
+
+// cardFilter добавляет к выборке неудалённых карт пользователя условия filter
+func cardFilter(query *gorm.DB, userID uint, filter models.CardFilter) *gorm.DB {
+	query = query.Scopes(notDeleted("cards")).Where("user_id = ?", userID)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.Currency != "" {
+		query = query.Where("balance_currency = ?", filter.Currency)
+	}
+	if filter.MinBalance != nil {
+		query = query.Where("balance_minor >= ?", *filter.MinBalance)
+	}
+	if filter.MaxBalance != nil {
+		query = query.Where("balance_minor <= ?", *filter.MaxBalance)
+	}
+	return query
+}
+
+// GetCardsPage возвращает страницу карт, подходящих под filter, и общее число таких карт
+func GetCardsPage(userID uint, filter models.CardFilter, params models.ListParams) (cards []models.Card, total int64, err error) {
+	err = cardFilter(db.GetDBConn().Model(&models.Card{}), userID, filter).Count(&total).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetCardsPage] cannot count cards. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+
+	err = listPage(cardFilter(db.GetDBConn().Model(&models.Card{}), userID, filter), params, cardSortColumns, "id").
+		Find(&cards).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetCardsPage] cannot get cards. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+	return cards, total, nil
+}
+
 func GetCardByID(userID, cardID uint) (models.Card, error) {
 	var card models.Card
-	err := db.GetDBConn().Where("id = ? AND user_id = ?", cardID, userID).First(&card).Error
+	err := db.GetDBConn().Scopes(notDeleted("cards")).Where("id = ? AND user_id = ?", cardID, userID).First(&card).Error
 	if err != nil {
-		return models.Card{}, err
+		logger.Error.Println("[repository.GetCardByID] cannot get card by id. Error is:", err.Error())
+		return models.Card{}, translateError(err)
 	}
 	return card, nil
 }
 
-func DeleteCard(cardID, userID uint) error {
-	err := db.GetDBConn().Model(&models.Card{}).
-		Where("id = ?", cardID).
-		Update("is_deleted", true).Error
+func DeleteCard(cardID, userID uint, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var card models.Card
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("cards")).
+			Where("id = ? AND user_id = ?", cardID, userID).
+			First(&card).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Card{}).
+			Where("id = ?", card.ID).
+			Updates(map[string]interface{}{"is_deleted": true, "deleted_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		return writeAudit(tx, card.UserID, models.AuditActionDelete, models.AuditEntityCard, card.ID, card, nil, meta)
+	})
 	if err != nil {
-		logger.Error.Println("[service.DeleteCard] cannot delete card. Error is:", err.Error())
+		logger.Error.Println("[repository.DeleteCard] cannot delete card. Error is:", err.Error())
 		return translateError(err)
 	}
 	return nil
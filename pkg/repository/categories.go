@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categoryReferences — таблицы, строки которых ссылаются на категорию через category_id
+var categoryReferences = []string{"transactions", "budgets", "recurring_rules"}
+
+// accessibleCategories ограничивает выборку категориями пользователя и общими категориями
+func accessibleCategories(query *gorm.DB, userID uint) *gorm.DB {
+	return query.Where("user_id = ? OR user_id IS NULL", userID)
+}
+
+func GetAllCategories(userID uint, categoryType string) (categories []models.Category, err error) {
+	query := accessibleCategories(db.GetDBConn().Model(&models.Category{}), userID)
+	if categoryType != "" {
+		query = query.Where("type = ?", categoryType)
+	}
+	err = query.Order("title").Order("id").Find(&categories).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllCategories] cannot get all categories. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return categories, nil
+}
+
+// GetCategoryByID возвращает категорию пользователя или общую категорию
+func GetCategoryByID(userID, categoryID uint) (category models.Category, err error) {
+	err = accessibleCategories(db.GetDBConn().Where("id = ?", categoryID), userID).First(&category).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetCategoryByID] cannot get category by id. Error is:", err.Error())
+		return models.Category{}, translateError(err)
+	}
+	return category, nil
+}
+
+func CreateCategory(category models.Category) error {
+	if err := db.GetDBConn().Create(&category).Error; err != nil {
+		logger.Error.Println("[repository.CreateCategory] cannot create category. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// seedCategories создаёт категории пользователя вместе с вложенными в них Children
+func seedCategories(tx *gorm.DB, userID uint, parentID *uint, categories []models.Category) error {
+	for _, category := range categories {
+		children := category.Children
+		category.UserID = &userID
+		category.ParentID = parentID
+		category.Children = nil
+		if err := tx.Create(&category).Error; err != nil {
+			return err
+		}
+		if err := seedCategories(tx, userID, &category.ID, children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateCategory обновляет название, родителя, иконку и цвет категории пользователя
+func UpdateCategory(category models.Category) error {
+	err := db.GetDBConn().Model(&models.Category{}).
+		Where("id = ? AND user_id = ?", category.ID, category.UserID).
+		Select("title", "parent_id", "icon", "color").
+		Updates(&category).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateCategory] cannot update category. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// IsCategoryDescendant проверяет, лежит ли категория categoryID внутри ancestorID (или совпадает с ней)
+func IsCategoryDescendant(categoryID, ancestorID uint) (bool, error) {
+	var count int64
+	err := db.GetDBConn().Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM categories WHERE id = @category
+			UNION
+			SELECT categories.id, categories.parent_id
+			FROM categories JOIN ancestors ON categories.id = ancestors.parent_id
+		)
+		SELECT COUNT(*) FROM ancestors WHERE id = @ancestor`,
+		map[string]interface{}{"category": categoryID, "ancestor": ancestorID},
+	).Scan(&count).Error
+	if err != nil {
+		logger.Error.Println("[repository.IsCategoryDescendant] cannot walk category ancestors. Error is:", err.Error())
+		return false, translateError(err)
+	}
+	return count > 0, nil
+}
+
+// DeleteCategory удаляет категорию пользователя, если на неё не ссылаются операции, бюджеты
+// и регулярные правила; вложенные категории переходят к её родителю
+func DeleteCategory(categoryID, userID uint) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", categoryID, userID).
+			First(&category).Error
+		if err != nil {
+			return err
+		}
+
+		for _, table := range categoryReferences {
+			var count int64
+			if err = tx.Table(table).Where("category_id = ?", categoryID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return errs.ErrCategoryInUse
+			}
+		}
+
+		err = tx.Model(&models.Category{}).Where("parent_id = ?", categoryID).Update("parent_id", category.ParentID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrCategoryInUse) {
+			return err
+		}
+		logger.Error.Println("[repository.DeleteCategory] cannot delete category. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// MergeCategories переносит в target все операции, регулярные правила и вложенные категории
+// категории sourceID и удаляет её. Бюджеты на месяцы, где у target уже есть бюджет,
+// складываются с ним; в разных валютах такие бюджеты не объединяются. Перенос каждой операции
+// и удаление source записываются в журнал.
+func MergeCategories(userID, sourceID uint, target models.Category, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var source models.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", sourceID, userID).
+			First(&source).Error
+		if err != nil {
+			return err
+		}
+
+		if err = mergeCategoryBudgets(tx, userID, sourceID, target.ID); err != nil {
+			return err
+		}
+		var moved []models.Transaction
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("category_id = ?", sourceID).Order("id").Find(&moved).Error
+		if err != nil {
+			return err
+		}
+		for _, table := range categoryReferences {
+			err = tx.Table(table).Where("category_id = ?", sourceID).Update("category_id", target.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+		for _, before := range moved {
+			after := before
+			after.CategoryID = &target.ID
+			err = writeAudit(tx, before.UserID, models.AuditActionUpdate, models.AuditEntityTransaction, before.ID, before, after, meta)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Если target вложена в source, она занимает место source, чтобы не получился цикл
+		if target.ParentID != nil && *target.ParentID == sourceID {
+			err = tx.Model(&models.Category{}).Where("id = ?", target.ID).Update("parent_id", source.ParentID).Error
+			if err != nil {
+				return err
+			}
+			var updated models.Category
+			if err = tx.Where("id = ?", target.ID).First(&updated).Error; err != nil {
+				return err
+			}
+			err = writeAudit(tx, userID, models.AuditActionUpdate, models.AuditEntityCategory, target.ID, target, updated, meta)
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Model(&models.Category{}).Where("parent_id = ?", sourceID).Update("parent_id", target.ID).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Delete(&source).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, userID, models.AuditActionDelete, models.AuditEntityCategory, source.ID, source, nil, meta)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrCurrencyMismatch) {
+			return err
+		}
+		logger.Error.Println("[repository.MergeCategories] cannot merge categories. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func mergeCategoryBudgets(tx *gorm.DB, userID, sourceID, targetID uint) error {
+	var budgets []models.Budget
+	err := tx.Where("user_id = ? AND category_id = ?", userID, sourceID).Find(&budgets).Error
+	if err != nil {
+		return err
+	}
+
+	for _, budget := range budgets {
+		var existing models.Budget
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND category_id = ? AND year = ? AND month = ?", userID, targetID, budget.Year, budget.Month).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Limit.Currency != budget.Limit.Currency {
+			return errs.ErrCurrencyMismatch
+		}
+
+		err = tx.Model(&existing).Update("limit_minor", existing.Limit.Minor+budget.Limit.Minor).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Delete(&budget).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// exportBatchSize — сколько записей выгрузка читает из БД за раз
+const exportBatchSize = 500
+
+// ExportTransactions передаёт в fn неудалённые операции пользователя за период [from, to)
+// пачками по exportBatchSize; нулевая граница периода не ограничивает
+func ExportTransactions(userID uint, from, to time.Time, fn func([]models.Transaction) error) error {
+	var batch []models.Transaction
+	query := db.GetDBConn().Scopes(notDeleted("transactions")).Where("user_id = ?", userID)
+	if !from.IsZero() {
+		query = query.Where("date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("date < ?", to)
+	}
+	err := query.FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+	if err != nil {
+		logger.Error.Println("[repository.ExportTransactions] cannot export transactions. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// ExportCards передаёт в fn неудалённые карты пользователя пачками по exportBatchSize
+func ExportCards(userID uint, fn func([]models.Card) error) error {
+	var batch []models.Card
+	err := db.GetDBConn().Scopes(notDeleted("cards")).Where("user_id = ?", userID).
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		logger.Error.Println("[repository.ExportCards] cannot export cards. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// ExportCategories передаёт в fn категории пользователя и общие категории пачками по exportBatchSize
+func ExportCategories(userID uint, fn func([]models.Category) error) error {
+	var batch []models.Category
+	err := accessibleCategories(db.GetDBConn(), userID).
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		logger.Error.Println("[repository.ExportCategories] cannot export categories. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
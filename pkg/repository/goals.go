@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func GetAllGoals(userID uint) ([]models.Goal, error) {
+	var goals []models.Goal
+	err := db.GetDBConn().Scopes(notDeleted("goals")).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&goals).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllGoals] cannot get all goals. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return goals, nil
+}
+
+func GetGoalByID(userID, goalID uint) (goal models.Goal, err error) {
+	err = db.GetDBConn().Scopes(notDeleted("goals")).
+		Where("id = ? AND user_id = ?", goalID, userID).
+		First(&goal).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetGoalByID] cannot get goal by id. Error is:", err.Error())
+		return models.Goal{}, translateError(err)
+	}
+	return goal, nil
+}
+
+// GetGoalOwnerID возвращает владельца цели; удалённые цели не находятся
+func GetGoalOwnerID(goalID uint) (ownerID uint, err error) {
+	var goal models.Goal
+	err = db.GetDBConn().Scopes(notDeleted("goals")).Select("user_id").Where("id = ?", goalID).First(&goal).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetGoalOwnerID] cannot find goal. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return goal.UserID, nil
+}
+
+func CreateGoal(goal models.Goal) error {
+	if err := db.GetDBConn().Create(&goal).Error; err != nil {
+		logger.Error.Println("[repository.CreateGoal] cannot create goal. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func UpdateGoal(goal models.Goal) error {
+	err := db.GetDBConn().Model(&models.Goal{}).Scopes(notDeleted("goals")).
+		Where("id = ? AND user_id = ?", goal.ID, goal.UserID).
+		Updates(map[string]interface{}{
+			"title":        goal.Title,
+			"description":  goal.Description,
+			"target_minor": goal.Target.Minor,
+			"deadline":     goal.Deadline,
+		}).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateGoal] cannot update goal. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func DeleteGoal(goalID, userID uint) error {
+	err := db.GetDBConn().Model(&models.Goal{}).
+		Where("id = ? AND user_id = ?", goalID, userID).
+		Update("is_deleted", true).Error
+	if err != nil {
+		logger.Error.Println("[repository.DeleteGoal] cannot delete goal. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func GetGoalContributions(userID, goalID uint) ([]models.GoalContribution, error) {
+	var contributions []models.GoalContribution
+	err := db.GetDBConn().
+		Where("goal_id = ? AND user_id = ?", goalID, userID).
+		Order("created_at").
+		Find(&contributions).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetGoalContributions] cannot get goal contributions. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return contributions, nil
+}
+
+// CreateGoalContribution списывает сумму с карты-источника, зачисляет её на карту цели,
+// увеличивает накопленное и сохраняет пополнение в одной транзакции. Каждое движение по карте
+// записывается в общий список операций как перевод и попадает в журнал изменений.
+func CreateGoalContribution(contribution models.GoalContribution, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var goal models.Goal
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("goals")).
+			Where("id = ? AND user_id = ?", contribution.GoalID, contribution.UserID).
+			First(&goal).Error
+		if err != nil {
+			return err
+		}
+
+		if contribution.CardID != nil {
+			if err = adjustCardBalance(tx, *contribution.CardID, contribution.UserID, contribution.Amount.Neg()); err != nil {
+				return err
+			}
+		}
+		if goal.CardID != nil {
+			if err = adjustCardBalance(tx, *goal.CardID, contribution.UserID, contribution.Credited); err != nil {
+				return err
+			}
+		}
+
+		err = tx.Model(&models.Goal{}).
+			Where("id = ?", goal.ID).
+			Update("saved_minor", gorm.Expr("saved_minor + ?", contribution.Credited.Minor)).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Create(&contribution).Error; err != nil {
+			return err
+		}
+
+		if contribution.CardID != nil {
+			err = createContributionTransaction(tx, contribution, goal, *contribution.CardID, contribution.Amount, meta)
+			if err != nil {
+				return err
+			}
+		}
+		if goal.CardID != nil {
+			return createContributionTransaction(tx, contribution, goal, *goal.CardID, contribution.Credited, meta)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.CreateGoalContribution] cannot create goal contribution. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// createContributionTransaction записывает движение пополнения цели по карте cardID: с карты-источника
+// списывается Amount, на карту цели зачисляется Credited. Направление видно по пополнению.
+func createContributionTransaction(tx *gorm.DB, contribution models.GoalContribution, goal models.Goal,
+	cardID uint, amount models.Money, meta models.AuditMeta) error {
+	transaction := models.Transaction{
+		Type:               models.TransactionTypeTransfer,
+		Amount:             amount,
+		Description:        goal.Title,
+		CardID:             &cardID,
+		GoalContributionID: &contribution.ID,
+		Date:               contribution.CreatedAt,
+		UserID:             contribution.UserID,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return err
+	}
+	return writeAudit(tx, transaction.UserID, models.AuditActionCreate, models.AuditEntityTransaction,
+		transaction.ID, nil, transaction, meta)
+}
@@ -13,3 +13,18 @@ func translateError(err error) error {
 
 	return err
 }
+
+// notDeleted ограничивает выборку неудалёнными строками таблицы table.
+// Таблица указывается явно, потому что запросы списков соединяют её с другими таблицами.
+func notDeleted(table string) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		return query.Where(table + ".is_deleted = false")
+	}
+}
+
+// onlyDeleted ограничивает выборку строками таблицы table, лежащими в корзине
+func onlyDeleted(table string) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		return query.Where(table + ".is_deleted = true")
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"testing"
+	"time"
+)
+
+// Удалённые операции и карты не попадают ни в траты бюджета, ни в поиск дублей импорта, ни в экспорт, ни в отчёты
+func TestDeletedRowsAreSkipped(t *testing.T) {
+	testdb.Open(t)
+	user := testdb.CreateUser(t, "owner")
+	card := testdb.CreateCard(t, user.ID, models.NewMoney(100000, "TJS"))
+	meta := models.AuditMeta{ActorID: user.ID}
+
+	category := models.Category{Title: "Groceries", Type: models.CategoryTypeOutcome, UserID: &user.ID}
+	if err := db.GetDBConn().Create(&category).Error; err != nil {
+		t.Fatalf("cannot create category: %v", err)
+	}
+
+	date := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	var transactions []models.Transaction
+	for _, minor := range []int64{1000, 2500} {
+		transaction := models.Transaction{
+			Type:        models.TransactionTypeExpense,
+			Amount:      models.NewMoney(minor, "TJS"),
+			Description: "groceries",
+			CategoryID:  &category.ID,
+			CardID:      &card.ID,
+			Date:        date,
+			UserID:      user.ID,
+		}
+		if err := CreateTransaction(&transaction, meta); err != nil {
+			t.Fatalf("CreateTransaction: %v", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+	deleted := transactions[1]
+	if err := DeleteTransaction(deleted.ID, user.ID, meta); err != nil {
+		t.Fatalf("DeleteTransaction: %v", err)
+	}
+
+	spending, err := GetCategorySpending(user.ID, category.ID, 2024, 3, "UTC")
+	if err != nil {
+		t.Fatalf("GetCategorySpending: %v", err)
+	}
+	if len(spending) != 1 || spending[0].Minor != 1000 {
+		t.Errorf("GetCategorySpending = %v, want only 1000 minor units", spending)
+	}
+
+	count, err := CountImportedTransactions(user.ID, models.TransactionTypeExpense, &card.ID, deleted.Amount, date, deleted.Description)
+	if err != nil {
+		t.Fatalf("CountImportedTransactions: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("CountImportedTransactions found %d deleted transactions, want 0", count)
+	}
+
+	var exported []models.Transaction
+	err = ExportTransactions(user.ID, date.AddDate(0, 0, -1), date.AddDate(0, 0, 1), func(batch []models.Transaction) error {
+		exported = append(exported, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportTransactions: %v", err)
+	}
+	if len(exported) != 1 || exported[0].ID != transactions[0].ID {
+		t.Errorf("ExportTransactions returned %d transactions, want only %d", len(exported), transactions[0].ID)
+	}
+
+	rows, err := GetReportByCategory(user.ID, date.AddDate(0, 0, -1), date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("GetReportByCategory: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Minor != 1000 {
+		t.Errorf("GetReportByCategory = %+v, want only 1000 minor units", rows)
+	}
+
+	if err = DeleteCard(card.ID, user.ID, meta); err != nil {
+		t.Fatalf("DeleteCard: %v", err)
+	}
+	var cards []models.Card
+	err = ExportCards(user.ID, func(batch []models.Card) error {
+		cards = append(cards, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportCards: %v", err)
+	}
+	if len(cards) != 0 {
+		t.Errorf("ExportCards returned %d deleted cards, want 0", len(cards))
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"time"
+)
+
+// CountImportedTransactions считает операции пользователя типа transactionType с той же суммой,
+// картой и описанием за тот же день, что и date — так распознаются уже импортированные строки выписки
+func CountImportedTransactions(userID uint, transactionType string, cardID *uint, amount models.Money, date time.Time, description string) (int64, error) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	query := db.GetDBConn().Model(&models.Transaction{}).Scopes(notDeleted("transactions")).
+		Where("user_id = ? AND type = ? AND amount_minor = ? AND amount_currency = ? AND description = ? AND date >= ? AND date < ?",
+			userID, transactionType, amount.Minor, amount.Currency, description, dayStart, dayStart.AddDate(0, 0, 1))
+	if cardID != nil {
+		query = query.Where("card_id = ?", *cardID)
+	} else {
+		query = query.Where("card_id IS NULL")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		logger.Error.Println("[repository.CountImportedTransactions] cannot count matching transactions. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return count, nil
+}
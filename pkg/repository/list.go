@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// listPage сортирует выборку по колонке sortColumns[params.Sort] и оставляет одну страницу.
+// Строки с равным значением упорядочиваются по idColumn, чтобы страницы не пересекались.
+func listPage(query *gorm.DB, params models.ListParams, sortColumns map[string]string, idColumn string) *gorm.DB {
+	desc := params.Order != models.SortOrderAsc
+	return query.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: sortColumns[params.Sort], Raw: true}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: idColumn, Raw: true}, Desc: desc}).
+		Offset(params.Offset()).
+		Limit(params.Limit)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetLoginLockedUntil возвращает самый поздний ещё не наступивший конец блокировки среди ключей keys;
+// нулевое время — вход не заблокирован
+func GetLoginLockedUntil(keys []string, now time.Time) (lockedUntil time.Time, err error) {
+	var until *time.Time
+	err = db.GetDBConn().Model(&models.LoginThrottle{}).
+		Select("MAX(locked_until)").
+		Where("key IN ? AND locked_until > ?", keys, now).
+		Scan(&until).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetLoginLockedUntil] cannot get login locks. Error is:", err.Error())
+		return lockedUntil, translateError(err)
+	}
+	if until != nil {
+		lockedUntil = *until
+	}
+	return lockedUntil, nil
+}
+
+// RegisterLoginFailure засчитывает неудачный вход по ключу key и возвращает число неудач подряд.
+// Если прошлая неудача была раньше windowStart, счёт начинается заново.
+func RegisterLoginFailure(key string, now, windowStart time.Time) (failures int, err error) {
+	err = db.GetDBConn().Raw(`
+		INSERT INTO login_throttles (key, failures, last_failure_at)
+		VALUES (@key, 1, @now)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < @window THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = @now
+		RETURNING failures`,
+		map[string]interface{}{"key": key, "now": now, "window": windowStart},
+	).Scan(&failures).Error
+	if err != nil {
+		logger.Error.Println("[repository.RegisterLoginFailure] cannot register login failure. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return failures, nil
+}
+
+// LockLogin запрещает вход по ключу key до момента until
+func LockLogin(key string, until time.Time) error {
+	err := db.GetDBConn().Model(&models.LoginThrottle{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+	if err != nil {
+		logger.Error.Println("[repository.LockLogin] cannot lock login. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// ResetLoginFailures сбрасывает счётчик неудачных входов по ключу key
+func ResetLoginFailures(key string) error {
+	err := db.GetDBConn().Where("key = ?", key).Delete(&models.LoginThrottle{}).Error
+	if err != nil {
+		logger.Error.Println("[repository.ResetLoginFailures] cannot reset login failures. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func CreateLoginAttempt(attempt *models.LoginAttempt) error {
+	if err := db.GetDBConn().Create(attempt).Error; err != nil {
+		logger.Error.Println("[repository.CreateLoginAttempt] cannot create login attempt. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// loginAttemptSortColumns — колонки сортировки журнала входов: по времени попытки
+var loginAttemptSortColumns = map[string]string{
+	models.SortByDate: "created_at",
+}
+
+// loginAttemptFilter добавляет к выборке журнала входов условия filter
+func loginAttemptFilter(query *gorm.DB, filter models.LoginAttemptFilter) *gorm.DB {
+	if filter.Username != "" {
+		query = query.Where("username = ?", filter.Username)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
+
+func GetLoginAttemptsPage(filter models.LoginAttemptFilter, params models.ListParams) (attempts []models.LoginAttempt, total int64, err error) {
+	err = loginAttemptFilter(db.GetDBConn().Model(&models.LoginAttempt{}), filter).Count(&total).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetLoginAttemptsPage] cannot count login attempts. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+
+	err = listPage(loginAttemptFilter(db.GetDBConn().Model(&models.LoginAttempt{}), filter), params, loginAttemptSortColumns, "id").
+		Find(&attempts).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetLoginAttemptsPage] cannot get login attempts. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+	return attempts, total, nil
+}
+
+// PurgeLoginRecords удаляет записи журнала входов старше attemptsBefore и счётчики,
+// у которых нет неудач после throttlesBefore и не действует блокировка
+func PurgeLoginRecords(attemptsBefore, throttlesBefore, now time.Time) (purged int64, err error) {
+	err = db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("created_at < ?", attemptsBefore).Delete(&models.LoginAttempt{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+
+		result = tx.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", throttlesBefore, now).
+			Delete(&models.LoginThrottle{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.PurgeLoginRecords] cannot purge login records. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return purged, nil
+}
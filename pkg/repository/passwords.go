@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ChangeUserPassword заменяет хеш пароля и отзывает все токены пользователя в одной транзакции
+func ChangeUserPassword(userID uint, passwordHash string, now time.Time) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		return setPasswordAndRevokeTokens(tx, userID, passwordHash, now)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.ChangeUserPassword] cannot change user password. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func CreatePasswordResetToken(token *models.PasswordResetToken) error {
+	if err := db.GetDBConn().Create(token).Error; err != nil {
+		logger.Error.Println("[repository.CreatePasswordResetToken] cannot create password reset token. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func GetPasswordResetTokenByHash(tokenHash string) (token models.PasswordResetToken, err error) {
+	err = db.GetDBConn().Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetPasswordResetTokenByHash] cannot get password reset token. Error is:", err.Error())
+		return token, translateError(err)
+	}
+	return token, nil
+}
+
+// ResetUserPassword гасит токен сброса tokenID и остальные неиспользованные токены пользователя,
+// заменяет хеш пароля и отзывает все токены сессий в одной транзакции.
+// Если токен уже использован параллельным запросом, возвращает errs.ErrInvalidResetToken.
+func ResetUserPassword(tokenID, userID uint, passwordHash string, now time.Time) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", tokenID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.ErrInvalidResetToken
+		}
+
+		err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", userID).
+			Update("used_at", now).Error
+		if err != nil {
+			return err
+		}
+		return setPasswordAndRevokeTokens(tx, userID, passwordHash, now)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidResetToken) {
+			return err
+		}
+		logger.Error.Println("[repository.ResetUserPassword] cannot reset user password. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func setPasswordAndRevokeTokens(tx *gorm.DB, userID uint, passwordHash string, now time.Time) error {
+	err := tx.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("password", passwordHash).Error
+	if err != nil {
+		return err
+	}
+	return revokeAllUserTokens(tx, userID, now)
+}
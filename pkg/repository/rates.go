@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+)
+
+func GetAllExchangeRates(userID uint) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	err := db.GetDBConn().
+		Where("user_id = ?", userID).
+		Order("base_currency, quote_currency").
+		Find(&rates).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllExchangeRates] cannot get exchange rates. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rates, nil
+}
+
+func GetExchangeRateByID(userID, rateID uint) (rate models.ExchangeRate, err error) {
+	err = db.GetDBConn().Where("id = ? AND user_id = ?", rateID, userID).First(&rate).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetExchangeRateByID] cannot get exchange rate by id. Error is:", err.Error())
+		return models.ExchangeRate{}, translateError(err)
+	}
+	return rate, nil
+}
+
+// GetExchangeRate ищет курс пользователя для пары валют base -> quote
+func GetExchangeRate(userID uint, base, quote string) (rate models.ExchangeRate, err error) {
+	err = db.GetDBConn().
+		Where("user_id = ? AND base_currency = ? AND quote_currency = ?", userID, base, quote).
+		First(&rate).Error
+	if err != nil {
+		return models.ExchangeRate{}, translateError(err)
+	}
+	return rate, nil
+}
+
+func CreateExchangeRate(rate models.ExchangeRate) error {
+	if err := db.GetDBConn().Create(&rate).Error; err != nil {
+		logger.Error.Println("[repository.CreateExchangeRate] cannot create exchange rate. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func UpdateExchangeRate(rate models.ExchangeRate) error {
+	err := db.GetDBConn().Model(&models.ExchangeRate{}).
+		Where("id = ? AND user_id = ?", rate.ID, rate.UserID).
+		Updates(map[string]interface{}{
+			"base_currency":  rate.BaseCurrency,
+			"quote_currency": rate.QuoteCurrency,
+			"rate":           rate.Rate,
+		}).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateExchangeRate] cannot update exchange rate. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func DeleteExchangeRate(rateID, userID uint) error {
+	err := db.GetDBConn().Where("id = ? AND user_id = ?", rateID, userID).Delete(&models.ExchangeRate{}).Error
+	if err != nil {
+		logger.Error.Println("[repository.DeleteExchangeRate] cannot delete exchange rate. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func GetAllRecurringRules(userID uint) ([]models.RecurringRule, error) {
+	var rules []models.RecurringRule
+	err := db.GetDBConn().Where("user_id = ?", userID).Order("id").Find(&rules).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllRecurringRules] cannot get recurring rules. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rules, nil
+}
+
+func GetRecurringRuleByID(userID, ruleID uint) (rule models.RecurringRule, err error) {
+	err = db.GetDBConn().Where("id = ? AND user_id = ?", ruleID, userID).First(&rule).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetRecurringRuleByID] cannot get recurring rule by id. Error is:", err.Error())
+		return models.RecurringRule{}, translateError(err)
+	}
+	return rule, nil
+}
+
+// GetRecurringRuleOwnerID возвращает владельца правила повторяющейся операции
+func GetRecurringRuleOwnerID(ruleID uint) (ownerID uint, err error) {
+	var rule models.RecurringRule
+	if err = db.GetDBConn().Select("user_id").Where("id = ?", ruleID).First(&rule).Error; err != nil {
+		logger.Error.Println("[repository.GetRecurringRuleOwnerID] cannot find recurring rule. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return rule.UserID, nil
+}
+
+// GetDueRecurringRules возвращает включённые правила с наступившей датой операции. Правила,
+// отложенные после неудачи, ждут своего RetryAt; правила удалённых учётных записей не выполняются.
+func GetDueRecurringRules(now time.Time, limit int) ([]models.RecurringRule, error) {
+	var rules []models.RecurringRule
+	err := db.GetDBConn().
+		Joins("JOIN users ON users.id = recurring_rules.user_id AND users.is_deleted = false").
+		Where("recurring_rules.is_active = true AND recurring_rules.next_run_at IS NOT NULL AND recurring_rules.next_run_at <= ?", now).
+		Where("recurring_rules.retry_at IS NULL OR recurring_rules.retry_at <= ?", now).
+		Order("recurring_rules.next_run_at").
+		Limit(limit).
+		Find(&rules).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetDueRecurringRules] cannot get due recurring rules. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rules, nil
+}
+
+func CreateRecurringRule(rule models.RecurringRule) error {
+	if err := db.GetDBConn().Create(&rule).Error; err != nil {
+		logger.Error.Println("[repository.CreateRecurringRule] cannot create recurring rule. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func UpdateRecurringRule(rule models.RecurringRule) error {
+	err := db.GetDBConn().Model(&models.RecurringRule{}).
+		Where("id = ? AND user_id = ?", rule.ID, rule.UserID).
+		Select("type", "description", "amount_minor", "amount_currency", "category_id", "card_id",
+			"frequency", "interval", "cron_expr", "start_date", "end_date", "next_run_at", "is_active",
+			"failures", "retry_at", "last_error").
+		Updates(&rule).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateRecurringRule] cannot update recurring rule. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func DeleteRecurringRule(ruleID, userID uint) error {
+	err := db.GetDBConn().Where("id = ? AND user_id = ?", ruleID, userID).Delete(&models.RecurringRule{}).Error
+	if err != nil {
+		logger.Error.Println("[repository.DeleteRecurringRule] cannot delete recurring rule. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// recurringAuditRequestID — идентификатор запроса в журнале изменений у операций регулярных правил
+const recurringAuditRequestID = "job:recurring"
+
+// MaterializeRecurringOccurrence создаёт операцию transaction за дату scheduledAt
+// и переносит правило на next в одной транзакции.
+// Повторный вызов для той же даты ничего не создаёт: created будет false.
+func MaterializeRecurringOccurrence(rule models.RecurringRule, scheduledAt time.Time, next *time.Time, transaction *models.Transaction) (created bool, err error) {
+	err = db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var locked models.RecurringRule
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", rule.ID).First(&locked).Error
+		if err != nil {
+			return err
+		}
+		// Другой экземпляр воркера уже обработал эту дату
+		if locked.NextRunAt == nil || !locked.NextRunAt.Equal(scheduledAt) {
+			return nil
+		}
+
+		occurrence := models.RecurringOccurrence{RuleID: rule.ID, ScheduledAt: scheduledAt}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&occurrence)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			if err := createTransaction(tx, transaction); err != nil {
+				return err
+			}
+			err = writeAudit(tx, transaction.UserID, models.AuditActionCreate, models.AuditEntityTransaction,
+				transaction.ID, nil, transaction, models.AuditMeta{ActorID: rule.UserID, RequestID: recurringAuditRequestID})
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&occurrence).Update("transaction_id", transaction.ID).Error
+			if err != nil {
+				return err
+			}
+			created = true
+		}
+
+		return tx.Model(&models.RecurringRule{}).
+			Where("id = ?", rule.ID).
+			Updates(map[string]interface{}{
+				"next_run_at": next,
+				"last_run_at": scheduledAt,
+				"failures":    0,
+				"retry_at":    nil,
+				"last_error":  "",
+			}).Error
+	})
+	if err != nil {
+		logger.Error.Printf("[repository.MaterializeRecurringOccurrence] cannot materialize rule %d at %s. Error is: %s\n", rule.ID, scheduledAt, err.Error())
+		return false, translateError(err)
+	}
+	return created, nil
+}
+
+// RecordRecurringFailure сохраняет счётчик неудач, время следующей попытки, причину неудачи
+// и признак активности правила
+func RecordRecurringFailure(rule models.RecurringRule) error {
+	err := db.GetDBConn().Model(&models.RecurringRule{}).
+		Where("id = ?", rule.ID).
+		Select("failures", "retry_at", "last_error", "is_active").
+		Updates(&rule).Error
+	if err != nil {
+		logger.Error.Println("[repository.RecordRecurringFailure] cannot record recurring rule failure. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ledger — подзапрос со всеми неудалёнными доходами и расходами пользователя за период в едином виде;
+// расходы получают kind = 'outcome'
+func ledger(userID uint, from, to time.Time) *gorm.DB {
+	return db.GetDBConn().Model(&models.Transaction{}).Scopes(notDeleted("transactions")).
+		Select("date, CASE WHEN type = ? THEN 'income' ELSE 'outcome' END AS kind, "+
+			"category_id, card_id, amount_currency AS currency, amount_minor AS minor", models.TransactionTypeIncome).
+		Where("user_id = ? AND type IN ? AND date >= ? AND date < ?",
+			userID, []string{models.TransactionTypeIncome, models.TransactionTypeExpense}, from, to)
+}
+
+// GetReportByPeriod суммирует операции по периодам date_trunc(unit) — day, week, month или year.
+// Границы периодов считаются в зоне timeZone — IANA-имени, а не в зоне соединения с БД.
+func GetReportByPeriod(userID uint, from, to time.Time, unit, timeZone string) ([]models.ReportRow, error) {
+	var rows []models.ReportRow
+	err := db.GetDBConn().Raw(`
+		SELECT date_trunc(@unit, ledger.date, @tz) AS period, ledger.kind, ledger.currency, SUM(ledger.minor) AS minor
+		FROM (@ledger) AS ledger
+		GROUP BY 1, 2, 3
+		ORDER BY 1`,
+		map[string]interface{}{"ledger": ledger(userID, from, to), "unit": unit, "tz": timeZone},
+	).Scan(&rows).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetReportByPeriod] cannot build report by period. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rows, nil
+}
+
+// GetReportByCategory суммирует расходы по категориям
+func GetReportByCategory(userID uint, from, to time.Time) ([]models.ReportRow, error) {
+	var rows []models.ReportRow
+	err := db.GetDBConn().Raw(`
+		SELECT ledger.category_id, COALESCE(categories.title, '') AS title, ledger.kind,
+			ledger.currency, SUM(ledger.minor) AS minor
+		FROM (@ledger) AS ledger
+		LEFT JOIN categories ON categories.id = ledger.category_id
+		WHERE ledger.kind = 'outcome'
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1`,
+		map[string]interface{}{"ledger": ledger(userID, from, to)},
+	).Scan(&rows).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetReportByCategory] cannot build report by category. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rows, nil
+}
+
+// GetReportByCard суммирует доходы и расходы по картам
+func GetReportByCard(userID uint, from, to time.Time) ([]models.ReportRow, error) {
+	var rows []models.ReportRow
+	err := db.GetDBConn().Raw(`
+		SELECT ledger.card_id, COALESCE(cards.card_number, '') AS card_number, ledger.kind,
+			ledger.currency, SUM(ledger.minor) AS minor
+		FROM (@ledger) AS ledger
+		JOIN cards ON cards.id = ledger.card_id
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1`,
+		map[string]interface{}{"ledger": ledger(userID, from, to)},
+	).Scan(&rows).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetReportByCard] cannot build report by card. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+func CreateRefreshToken(token *models.RefreshToken) error {
+	if err := db.GetDBConn().Create(token).Error; err != nil {
+		logger.Error.Println("[repository.CreateRefreshToken] cannot create refresh token. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func GetRefreshTokenByHash(tokenHash string) (token models.RefreshToken, err error) {
+	err = db.GetDBConn().Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetRefreshTokenByHash] cannot get refresh token. Error is:", err.Error())
+		return token, translateError(err)
+	}
+	return token, nil
+}
+
+// RotateRefreshToken отзывает токен currentID и сохраняет next в одной транзакции.
+// Если токен уже отозван параллельным запросом, возвращает errs.ErrInvalidToken.
+func RotateRefreshToken(currentID uint, next *models.RefreshToken, now time.Time) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", currentID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.ErrInvalidToken
+		}
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidToken) {
+			return err
+		}
+		logger.Error.Println("[repository.RotateRefreshToken] cannot rotate refresh token. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily отзывает все действующие токены цепочки familyID пользователя
+func RevokeRefreshTokenFamily(userID uint, familyID string, now time.Time) error {
+	err := db.GetDBConn().Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", now).Error
+	if err != nil {
+		logger.Error.Println("[repository.RevokeRefreshTokenFamily] cannot revoke refresh tokens. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// RevokeAccessToken вносит access-токен jti в список отозванных до момента его истечения
+func RevokeAccessToken(jti string, expiresAt time.Time) error {
+	err := db.GetDBConn().
+		Where(models.RevokedToken{JTI: jti}).
+		FirstOrCreate(&models.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+	if err != nil {
+		logger.Error.Println("[repository.RevokeAccessToken] cannot revoke access token. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// RevokeAllUserTokens отзывает все refresh-токены пользователя и запоминает момент,
+// раньше которого выданные access-токены больше не принимаются
+func RevokeAllUserTokens(userID uint, now time.Time) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		return revokeAllUserTokens(tx, userID, now)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.RevokeAllUserTokens] cannot revoke user tokens. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func revokeAllUserTokens(tx *gorm.DB, userID uint, now time.Time) error {
+	err := tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("tokens_revoked_at", now).Error
+}
+
+// IsAccessTokenRevoked проверяет, отозван ли access-токен jti пользователя userID,
+// выданный в issuedAt: по списку отозванных токенов или выходом из всех сессий
+func IsAccessTokenRevoked(userID uint, jti string, issuedAt time.Time) (revoked bool, err error) {
+	err = db.GetDBConn().Raw(`
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)
+			OR EXISTS (SELECT 1 FROM users WHERE id = ? AND tokens_revoked_at > ?)`,
+		jti, userID, issuedAt).Scan(&revoked).Error
+	if err != nil {
+		logger.Error.Println("[repository.IsAccessTokenRevoked] cannot check access token. Error is:", err.Error())
+		return false, translateError(err)
+	}
+	return revoked, nil
+}
+
+// PurgeExpiredTokens удаляет истёкшие refresh-токены, записи об отозванных access-токенах,
+// токены сброса пароля и незавершённые входы с двухфакторной аутентификацией
+func PurgeExpiredTokens(now time.Time) (purged int64, err error) {
+	expiring := []interface{}{
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.PasswordResetToken{},
+		&models.TwoFactorChallenge{},
+	}
+	err = db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		for _, model := range expiring {
+			result := tx.Where("expires_at < ?", now).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.PurgeExpiredTokens] cannot purge expired tokens. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// transactionSortColumns — колонки сортировки списка операций; категории сортируются по названию
+var transactionSortColumns = map[string]string{
+	models.SortByDate:     "transactions.date",
+	models.SortByAmount:   "transactions.amount_minor",
+	models.SortByCategory: "categories.title",
+}
+
+// transactionFilter добавляет к выборке неудалённых операций пользователя условия filter
+func transactionFilter(query *gorm.DB, userID uint, filter models.TransactionFilter) *gorm.DB {
+	query = query.Scopes(notDeleted("transactions")).Where("transactions.user_id = ?", userID)
+	if filter.Type != "" {
+		query = query.Where("transactions.type = ?", filter.Type)
+	}
+	if len(filter.CardIDs) > 0 {
+		query = query.Where("transactions.card_id IN ?", filter.CardIDs)
+	}
+	if filter.HasCard != nil {
+		if *filter.HasCard {
+			query = query.Where("transactions.card_id IS NOT NULL")
+		} else {
+			query = query.Where("transactions.card_id IS NULL")
+		}
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("transactions.category_id IN ?", filter.CategoryIDs)
+	}
+	if filter.Tag != "" {
+		query = query.Where("transactions.tags @> ?::jsonb", models.Tags{filter.Tag})
+	}
+	if filter.Query != "" {
+		query = query.Where("transactions.description iLIKE ?", "%"+filter.Query+"%")
+	}
+	if filter.From != nil {
+		query = query.Where("transactions.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transactions.date < ?", *filter.To)
+	}
+	if filter.Currency != "" {
+		query = query.Where("transactions.amount_currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("transactions.amount_minor >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("transactions.amount_minor <= ?", *filter.MaxAmount)
+	}
+	return query
+}
+
+// GetAllTransactions возвращает страницу операций, подходящих под filter, и общее число таких операций
+func GetAllTransactions(userID uint, filter models.TransactionFilter, params models.ListParams) (transactions []models.Transaction, total int64, err error) {
+	err = transactionFilter(db.GetDBConn().Model(&models.Transaction{}), userID, filter).Count(&total).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllTransactions] cannot count transactions. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+
+	query := db.GetDBConn().Model(&models.Transaction{}).Select("transactions.*")
+	if params.Sort == models.SortByCategory {
+		query = query.Joins("LEFT JOIN categories ON categories.id = transactions.category_id")
+	}
+	err = listPage(transactionFilter(query, userID, filter), params, transactionSortColumns, "transactions.id").
+		Find(&transactions).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllTransactions] cannot get all transactions. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+	return transactions, total, nil
+}
+
+func GetTransactionByID(userID, transactionID uint) (transaction models.Transaction, err error) {
+	err = db.GetDBConn().Scopes(notDeleted("transactions")).
+		Where("id = ? AND user_id = ?", transactionID, userID).
+		First(&transaction).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetTransactionByID] cannot get transaction by id. Error is:", err.Error())
+		return models.Transaction{}, translateError(err)
+	}
+	return transaction, nil
+}
+
+// GetTransactionOwnerID возвращает владельца операции, в том числе удалённой
+func GetTransactionOwnerID(transactionID uint) (ownerID uint, err error) {
+	var transaction models.Transaction
+	err = db.GetDBConn().Select("user_id").Where("id = ?", transactionID).First(&transaction).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetTransactionOwnerID] cannot find transaction. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return transaction.UserID, nil
+}
+
+// CreateTransaction сохраняет операцию, меняет баланс её карты и записывает изменение в журнал
+// в одной транзакции
+func CreateTransaction(transaction *models.Transaction, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		if err := createTransaction(tx, transaction); err != nil {
+			return err
+		}
+		return writeAudit(tx, transaction.UserID, models.AuditActionCreate, models.AuditEntityTransaction,
+			transaction.ID, nil, transaction, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.CreateTransaction] cannot create transaction. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func createTransaction(tx *gorm.DB, transaction *models.Transaction) error {
+	if err := tx.Create(transaction).Error; err != nil {
+		return err
+	}
+	return applyTransactionToCard(tx, *transaction, 1)
+}
+
+// UpdateTransaction заменяет сумму, описание, карту, категорию, дату, её зону и метки операции
+// и корректирует балансы карт на разницу между старой и новой записью
+func UpdateTransaction(transaction models.Transaction, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var old models.Transaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("transactions")).
+			Where("id = ? AND user_id = ?", transaction.ID, transaction.UserID).
+			First(&old).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.Transaction{}).
+			Where("id = ?", old.ID).
+			Select("amount_minor", "amount_currency", "description", "card_id", "category_id", "date", "time_zone", "tags").
+			Updates(&transaction).Error
+		if err != nil {
+			return err
+		}
+
+		// Возвращаем старую сумму на старую карту и проводим новую по новой карте
+		if err = applyTransactionToCard(tx, old, -1); err != nil {
+			return err
+		}
+		transaction.Type = old.Type
+		if err = applyTransactionToCard(tx, transaction, 1); err != nil {
+			return err
+		}
+
+		var after models.Transaction
+		if err = tx.Where("id = ?", old.ID).First(&after).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, old.UserID, models.AuditActionUpdate, models.AuditEntityTransaction,
+			old.ID, old, after, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.UpdateTransaction] cannot update transaction. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// DeleteTransaction помечает операцию удалённой и отменяет её влияние на баланс карты
+func DeleteTransaction(transactionID, userID uint, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var transaction models.Transaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("transactions")).
+			Where("id = ? AND user_id = ?", transactionID, userID).
+			First(&transaction).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Transaction{}).
+			Where("id = ?", transaction.ID).
+			Updates(map[string]interface{}{"is_deleted": true, "deleted_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		if err = applyTransactionToCard(tx, transaction, -1); err != nil {
+			return err
+		}
+		return writeAudit(tx, transaction.UserID, models.AuditActionDelete, models.AuditEntityTransaction,
+			transaction.ID, transaction, nil, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.DeleteTransaction] cannot delete transaction. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// applyTransactionToCard проводит (sign = 1) или отменяет (sign = -1) операцию по её карте:
+// доход зачисляется, расход списывается. Переводы меняют балансы сами, в /api/transfers.
+func applyTransactionToCard(tx *gorm.DB, transaction models.Transaction, sign int64) error {
+	if transaction.CardID == nil {
+		return nil
+	}
+	switch transaction.Type {
+	case models.TransactionTypeIncome:
+	case models.TransactionTypeExpense:
+		sign = -sign
+	default:
+		return nil
+	}
+	delta := models.NewMoney(sign*transaction.Amount.Minor, transaction.Amount.Currency)
+	return adjustCardBalance(tx, *transaction.CardID, transaction.UserID, delta)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUpdateTransactionMovesCardBalances(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		sign int64 // +1 — доход зачисляется на карту, -1 — расход списывается
+	}{
+		{"income", models.TransactionTypeIncome, 1},
+		{"expense", models.TransactionTypeExpense, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testdb.Open(t)
+			user := testdb.CreateUser(t, "owner")
+			first := testdb.CreateCard(t, user.ID, models.NewMoney(100000, "TJS"))
+			second := testdb.CreateCard(t, user.ID, models.NewMoney(50000, "TJS"))
+			meta := models.AuditMeta{ActorID: user.ID}
+
+			transaction := models.Transaction{
+				Type:        tt.typ,
+				Amount:      models.NewMoney(2500, "TJS"),
+				Description: "groceries",
+				CardID:      &first.ID,
+				Date:        time.Now(),
+				UserID:      user.ID,
+			}
+			if err := CreateTransaction(&transaction, meta); err != nil {
+				t.Fatalf("CreateTransaction: %v", err)
+			}
+			assertBalance(t, first.ID, 100000+tt.sign*2500)
+
+			// Новая сумма на той же карте заменяет старую
+			transaction.Amount = models.NewMoney(4000, "TJS")
+			if err := UpdateTransaction(transaction, meta); err != nil {
+				t.Fatalf("UpdateTransaction (amount): %v", err)
+			}
+			assertBalance(t, first.ID, 100000+tt.sign*4000)
+			assertBalance(t, second.ID, 50000)
+
+			// Перенос на другую карту возвращает сумму первой карте и проводит её по второй
+			transaction.CardID = &second.ID
+			if err := UpdateTransaction(transaction, meta); err != nil {
+				t.Fatalf("UpdateTransaction (card): %v", err)
+			}
+			assertBalance(t, first.ID, 100000)
+			assertBalance(t, second.ID, 50000+tt.sign*4000)
+
+			// Смена карты и суммы сразу
+			transaction.CardID = &first.ID
+			transaction.Amount = models.NewMoney(1000, "TJS")
+			if err := UpdateTransaction(transaction, meta); err != nil {
+				t.Fatalf("UpdateTransaction (card and amount): %v", err)
+			}
+			assertBalance(t, first.ID, 100000+tt.sign*1000)
+			assertBalance(t, second.ID, 50000)
+		})
+	}
+}
+
+func assertBalance(t *testing.T, cardID uint, want int64) {
+	t.Helper()
+	if got := testdb.CardBalance(t, cardID); got.Minor != want {
+		t.Errorf("card %d balance = %s, want %d minor units", cardID, got, want)
+	}
+}
+
+// Операцию, карта которой в корзине, нельзя изменить или удалить: ErrCardDeleted, а не «не найдено»
+func TestTransactionOfDeletedCard(t *testing.T) {
+	testdb.Open(t)
+	user := testdb.CreateUser(t, "owner")
+	card := testdb.CreateCard(t, user.ID, models.NewMoney(100000, "TJS"))
+	other := testdb.CreateCard(t, user.ID, models.NewMoney(50000, "TJS"))
+	meta := models.AuditMeta{ActorID: user.ID}
+
+	transaction := models.Transaction{
+		Type:        models.TransactionTypeExpense,
+		Amount:      models.NewMoney(2500, "TJS"),
+		Description: "groceries",
+		CardID:      &card.ID,
+		Date:        time.Now(),
+		UserID:      user.ID,
+	}
+	if err := CreateTransaction(&transaction, meta); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	if err := DeleteCard(card.ID, user.ID, meta); err != nil {
+		t.Fatalf("DeleteCard: %v", err)
+	}
+
+	moved := transaction
+	moved.CardID = &other.ID
+	if err := UpdateTransaction(moved, meta); !errors.Is(err, errs.ErrCardDeleted) {
+		t.Errorf("UpdateTransaction error = %v, want ErrCardDeleted", err)
+	}
+	if err := DeleteTransaction(transaction.ID, user.ID, meta); !errors.Is(err, errs.ErrCardDeleted) {
+		t.Errorf("DeleteTransaction error = %v, want ErrCardDeleted", err)
+	}
+	assertBalance(t, card.ID, 100000-2500)
+	assertBalance(t, other.ID, 50000)
+}
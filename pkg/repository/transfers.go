@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func GetAllTransfers(userID uint) ([]models.Transfer, error) {
+	var transfers []models.Transfer
+	err := db.GetDBConn().
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&transfers).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetAllTransfers] cannot get all transfers. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return transfers, nil
+}
+
+func GetTransferByID(userID, transferID uint) (transfer models.Transfer, err error) {
+	err = db.GetDBConn().Where("id = ? AND user_id = ?", transferID, userID).First(&transfer).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetTransferByID] cannot get transfer by id. Error is:", err.Error())
+		return models.Transfer{}, translateError(err)
+	}
+	return transfer, nil
+}
+
+// GetTransferOwnerID возвращает владельца перевода, в том числе отменённого
+func GetTransferOwnerID(transferID uint) (ownerID uint, err error) {
+	var transfer models.Transfer
+	if err = db.GetDBConn().Select("user_id").Where("id = ?", transferID).First(&transfer).Error; err != nil {
+		logger.Error.Println("[repository.GetTransferOwnerID] cannot find transfer. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return transfer.UserID, nil
+}
+
+// CreateTransfer списывает сумму с комиссией с одной карты, зачисляет на другую
+// и сохраняет перевод вместе с движениями по обеим картам в общем списке операций в одной транзакции.
+// Перевод, его движения и изменения балансов карт записываются в журнал.
+func CreateTransfer(transfer models.Transfer, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		debit := models.NewMoney(transfer.Amount.Minor+transfer.Fee.Minor, transfer.Amount.Currency)
+		if err := adjustAuditedCardBalance(tx, transfer.FromCardID, transfer.UserID, debit.Neg(), meta); err != nil {
+			return err
+		}
+		if err := adjustAuditedCardBalance(tx, transfer.ToCardID, transfer.UserID, transfer.Credited, meta); err != nil {
+			return err
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+		err := writeAudit(tx, transfer.UserID, models.AuditActionCreate, models.AuditEntityTransfer, transfer.ID, nil, transfer, meta)
+		if err != nil {
+			return err
+		}
+		if err = createTransferTransaction(tx, transfer, transfer.FromCardID, debit, meta); err != nil {
+			return err
+		}
+		return createTransferTransaction(tx, transfer, transfer.ToCardID, transfer.Credited, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.CreateTransfer] cannot create transfer. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// createTransferTransaction записывает движение перевода по карте cardID: с карты-источника
+// списываются Amount + Fee, на карту-получатель зачисляется Credited. Направление видно по переводу.
+func createTransferTransaction(tx *gorm.DB, transfer models.Transfer, cardID uint, amount models.Money, meta models.AuditMeta) error {
+	transaction := models.Transaction{
+		Type:        models.TransactionTypeTransfer,
+		Amount:      amount,
+		Description: transfer.Description,
+		CardID:      &cardID,
+		TransferID:  &transfer.ID,
+		Date:        transfer.CreatedAt,
+		UserID:      transfer.UserID,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return err
+	}
+	return writeAudit(tx, transaction.UserID, models.AuditActionCreate, models.AuditEntityTransaction,
+		transaction.ID, nil, transaction, meta)
+}
+
+// CancelTransfer возвращает деньги на карту-источник, списывает их с карты-получателя
+// и помечает перевод отменённым, а обе его записи в списке операций — удалёнными.
+// Все изменения записываются в журнал.
+func CancelTransfer(transferID, userID uint, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var transfer models.Transfer
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", transferID, userID).
+			First(&transfer).Error
+		if err != nil {
+			return err
+		}
+		if transfer.Status == models.TransferStatusCancelled {
+			return errs.ErrTransferAlreadyCancelled
+		}
+
+		debit := models.NewMoney(transfer.Amount.Minor+transfer.Fee.Minor, transfer.Amount.Currency)
+		if err = adjustAuditedCardBalance(tx, transfer.FromCardID, transfer.UserID, debit, meta); err != nil {
+			return err
+		}
+		if err = adjustAuditedCardBalance(tx, transfer.ToCardID, transfer.UserID, transfer.Credited.Neg(), meta); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&models.Transfer{}).
+			Where("id = ?", transfer.ID).
+			Updates(map[string]interface{}{
+				"status":       models.TransferStatusCancelled,
+				"cancelled_at": &now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		var cancelled models.Transfer
+		if err = tx.Where("id = ?", transfer.ID).First(&cancelled).Error; err != nil {
+			return err
+		}
+		err = writeAudit(tx, transfer.UserID, models.AuditActionUpdate, models.AuditEntityTransfer, transfer.ID, transfer, cancelled, meta)
+		if err != nil {
+			return err
+		}
+
+		var legs []models.Transaction
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(notDeleted("transactions")).
+			Where("transfer_id = ?", transfer.ID).
+			Order("id").
+			Find(&legs).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.Transaction{}).
+			Where("transfer_id = ?", transfer.ID).
+			Updates(map[string]interface{}{"is_deleted": true, "deleted_at": &now}).Error
+		if err != nil {
+			return err
+		}
+		for _, leg := range legs {
+			err = writeAudit(tx, leg.UserID, models.AuditActionDelete, models.AuditEntityTransaction, leg.ID, leg, nil, meta)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.CancelTransfer] cannot cancel transfer. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
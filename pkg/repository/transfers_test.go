@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"testing"
+)
+
+// Перевод записывает движение по каждой карте, отмена убирает оба
+func TestTransferRecordsBothCards(t *testing.T) {
+	testdb.Open(t)
+	user := testdb.CreateUser(t, "owner")
+	from := testdb.CreateCard(t, user.ID, models.NewMoney(100000, "TJS"))
+	to := testdb.CreateCard(t, user.ID, models.NewMoney(0, "USD"))
+
+	transfer := models.Transfer{
+		FromCardID: from.ID,
+		ToCardID:   to.ID,
+		Amount:     models.NewMoney(10000, "TJS"),
+		Fee:        models.NewMoney(150, "TJS"),
+		Credited:   models.NewMoney(915, "USD"),
+		Status:     models.TransferStatusCompleted,
+		UserID:     user.ID,
+	}
+	if err := CreateTransfer(transfer, models.AuditMeta{ActorID: user.ID}); err != nil {
+		t.Fatalf("CreateTransfer: %v", err)
+	}
+	assertBalance(t, from.ID, 100000-10150)
+	assertBalance(t, to.ID, 915)
+
+	var legs []models.Transaction
+	if err := db.GetDBConn().Where("user_id = ? AND is_deleted = false", user.ID).Order("id").Find(&legs).Error; err != nil {
+		t.Fatalf("cannot get transfer transactions: %v", err)
+	}
+	if len(legs) != 2 {
+		t.Fatalf("transfer recorded %d transactions, want 2", len(legs))
+	}
+	if *legs[0].CardID != from.ID || legs[0].Amount != models.NewMoney(10150, "TJS") {
+		t.Errorf("debit = %s on card %d, want 101.50 TJS on card %d", legs[0].Amount, *legs[0].CardID, from.ID)
+	}
+	if *legs[1].CardID != to.ID || legs[1].Amount != models.NewMoney(915, "USD") {
+		t.Errorf("credit = %s on card %d, want 9.15 USD on card %d", legs[1].Amount, *legs[1].CardID, to.ID)
+	}
+
+	if err := CancelTransfer(*legs[0].TransferID, user.ID, models.AuditMeta{ActorID: user.ID}); err != nil {
+		t.Fatalf("CancelTransfer: %v", err)
+	}
+	assertBalance(t, from.ID, 100000)
+	assertBalance(t, to.ID, 0)
+
+	var live int64
+	if err := db.GetDBConn().Model(&models.Transaction{}).Where("user_id = ? AND is_deleted = false", user.ID).Count(&live).Error; err != nil {
+		t.Fatalf("cannot count transactions: %v", err)
+	}
+	if live != 0 {
+		t.Errorf("%d transfer transactions left after cancel, want 0", live)
+	}
+
+	// Журнал: создание и отмена перевода, по два изменения каждой карты, создание и удаление обоих движений
+	want := map[string]int64{models.AuditEntityTransfer: 2, models.AuditEntityCard: 4, models.AuditEntityTransaction: 4}
+	for entity, count := range want {
+		var got int64
+		err := db.GetDBConn().Model(&models.AuditEntry{}).Where("user_id = ? AND entity_type = ?", user.ID, entity).Count(&got).Error
+		if err != nil {
+			t.Fatalf("cannot count audit entries: %v", err)
+		}
+		if got != count {
+			t.Errorf("%d audit entries of %s, want %d", got, entity, count)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+// Строки корзины по видам записей. Записи переводов отменяются через /api/transfers
+// и в корзину не попадают. Параметр @user.
+var trashQueries = map[string]string{
+	models.TrashEntityTransaction: `
+		SELECT 'transaction' AS entity_type, id AS entity_id, type AS kind, description AS title,
+			amount_minor, amount_currency, deleted_at
+		FROM transactions
+		WHERE user_id = @user AND is_deleted = true AND type <> 'transfer'`,
+	models.TrashEntityCard: `
+		SELECT 'card' AS entity_type, id AS entity_id, '' AS kind, card_number AS title,
+			balance_minor AS amount_minor, balance_currency AS amount_currency, deleted_at
+		FROM cards
+		WHERE user_id = @user AND is_deleted = true`,
+}
+
+// trashSortColumns — колонки сортировки корзины: по времени удаления
+var trashSortColumns = map[string]string{
+	models.SortByDate: "deleted_at",
+}
+
+func trashQuery(userID uint, filter models.TrashFilter) *gorm.DB {
+	var parts []string
+	for _, entityType := range []string{models.TrashEntityTransaction, models.TrashEntityCard} {
+		if filter.EntityType == "" || filter.EntityType == entityType {
+			parts = append(parts, trashQueries[entityType])
+		}
+	}
+	items := db.GetDBConn().Raw(strings.Join(parts, " UNION ALL "), map[string]interface{}{"user": userID})
+	return db.GetDBConn().Table("(?) AS trash", items)
+}
+
+// GetTrashPage возвращает страницу корзины пользователя и общее число записей в ней
+func GetTrashPage(userID uint, filter models.TrashFilter, params models.ListParams) (items []models.TrashItem, total int64, err error) {
+	if err = trashQuery(userID, filter).Count(&total).Error; err != nil {
+		logger.Error.Println("[repository.GetTrashPage] cannot count trash items. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+
+	err = listPage(trashQuery(userID, filter), params, trashSortColumns, "entity_id").Find(&items).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetTrashPage] cannot get trash items. Error is:", err.Error())
+		return nil, 0, translateError(err)
+	}
+	return items, total, nil
+}
+
+// GetDeletedTransaction возвращает операцию пользователя из корзины
+func GetDeletedTransaction(userID, transactionID uint) (transaction models.Transaction, err error) {
+	err = db.GetDBConn().Scopes(onlyDeleted("transactions")).
+		Where("id = ? AND user_id = ? AND type <> ?", transactionID, userID, models.TransactionTypeTransfer).
+		First(&transaction).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetDeletedTransaction] cannot get deleted transaction. Error is:", err.Error())
+		return models.Transaction{}, translateError(err)
+	}
+	return transaction, nil
+}
+
+// RestoreTransaction возвращает операцию из корзины и снова проводит её по карте
+func RestoreTransaction(transactionID, userID uint, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var transaction models.Transaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(onlyDeleted("transactions")).
+			Where("id = ? AND user_id = ? AND type <> ?", transactionID, userID, models.TransactionTypeTransfer).
+			First(&transaction).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Transaction{}).
+			Where("id = ?", transaction.ID).
+			Updates(map[string]interface{}{"is_deleted": false, "deleted_at": nil}).Error
+		if err != nil {
+			return err
+		}
+		if err = applyTransactionToCard(tx, transaction, 1); err != nil {
+			return err
+		}
+
+		var restored models.Transaction
+		if err = tx.Where("id = ?", transaction.ID).First(&restored).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, transaction.UserID, models.AuditActionRestore, models.AuditEntityTransaction,
+			transaction.ID, nil, restored, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.RestoreTransaction] cannot restore transaction. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// RestoreCard возвращает карту из корзины с тем балансом, который был у неё при удалении
+func RestoreCard(cardID, userID uint, meta models.AuditMeta) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var card models.Card
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(onlyDeleted("cards")).
+			Where("id = ? AND user_id = ?", cardID, userID).
+			First(&card).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Card{}).
+			Where("id = ?", card.ID).
+			Updates(map[string]interface{}{"is_deleted": false, "deleted_at": nil}).Error
+		if err != nil {
+			return err
+		}
+
+		var restored models.Card
+		if err = tx.Where("id = ?", card.ID).First(&restored).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, card.UserID, models.AuditActionRestore, models.AuditEntityCard, card.ID, nil, restored, meta)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.RestoreCard] cannot restore card. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// PurgeTrash окончательно удаляет записи, попавшие в корзину раньше before. Сначала удаляются
+// операции, затем карты, на которые больше ничего не ссылается: карта, к которой привязаны
+// неудалённые операции, переводы, цели или регулярные правила, остаётся в корзине.
+// Отметки recurring_occurrences об удалённых операциях сохраняются, чтобы правила не создали их заново.
+func PurgeTrash(before time.Time) (transactions, cards int64, err error) {
+	err = db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			DELETE FROM transactions
+			WHERE is_deleted = true AND type <> ? AND deleted_at < ?`,
+			models.TransactionTypeTransfer, before)
+		if result.Error != nil {
+			return result.Error
+		}
+		transactions = result.RowsAffected
+
+		result = tx.Exec(`
+			DELETE FROM cards
+			WHERE is_deleted = true AND deleted_at < ?
+				AND NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.card_id = cards.id)
+				AND NOT EXISTS (SELECT 1 FROM transfers WHERE transfers.from_card_id = cards.id OR transfers.to_card_id = cards.id)
+				AND NOT EXISTS (SELECT 1 FROM goals WHERE goals.card_id = cards.id)
+				AND NOT EXISTS (SELECT 1 FROM goal_contributions WHERE goal_contributions.card_id = cards.id)
+				AND NOT EXISTS (SELECT 1 FROM recurring_rules WHERE recurring_rules.card_id = cards.id)`,
+			before)
+		if result.Error != nil {
+			return result.Error
+		}
+		cards = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.PurgeTrash] cannot purge trash. Error is:", err.Error())
+		return 0, 0, translateError(err)
+	}
+	return transactions, cards, nil
+}
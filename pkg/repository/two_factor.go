@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// SetUserTOTPSecret сохраняет новый, ещё не подтверждённый секрет TOTP. Если двухфакторная
+// аутентификация уже включена, возвращает errs.ErrTwoFactorAlreadyEnabled.
+func SetUserTOTPSecret(userID uint, secret string) error {
+	result := db.GetDBConn().Model(&models.User{}).
+		Where("id = ? AND two_factor_enabled = false", userID).
+		Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0})
+	if result.Error != nil {
+		logger.Error.Println("[repository.SetUserTOTPSecret] cannot set totp secret. Error is:", result.Error.Error())
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+// EnableTwoFactor включает двухфакторную аутентификацию, запоминает шаг подтверждающего кода
+// и заменяет коды восстановления пользователя
+func EnableTwoFactor(userID uint, step int64, codeHashes []string) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND two_factor_enabled = false", userID).
+			Updates(map[string]interface{}{"two_factor_enabled": true, "totp_last_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.ErrTwoFactorAlreadyEnabled
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrTwoFactorAlreadyEnabled) {
+			return err
+		}
+		logger.Error.Println("[repository.EnableTwoFactor] cannot enable two-factor authentication. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// DisableTwoFactor выключает двухфакторную аутентификацию и удаляет секрет и коды восстановления
+func DisableTwoFactor(userID uint) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{"two_factor_enabled": false, "totp_secret": "", "totp_last_step": 0}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	})
+	if err != nil {
+		logger.Error.Println("[repository.DisableTwoFactor] cannot disable two-factor authentication. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// SetUserTwoFactorRequired обязывает пользователя подключить двухфакторную аутентификацию или снимает обязанность
+func SetUserTwoFactorRequired(userID uint, required bool) error {
+	err := db.GetDBConn().Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("two_factor_required", required).Error
+	if err != nil {
+		logger.Error.Println("[repository.SetUserTwoFactorRequired] cannot set two-factor requirement. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// UseTOTPStep запоминает шаг принятого кода TOTP. Если код этого или более позднего шага
+// уже принимался, возвращает errs.ErrInvalidTwoFactorCode.
+func UseTOTPStep(userID uint, step int64) error {
+	result := db.GetDBConn().Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		logger.Error.Println("[repository.UseTOTPStep] cannot save totp step. Error is:", result.Error.Error())
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes заменяет все коды восстановления пользователя новыми
+func ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if err != nil {
+		logger.Error.Println("[repository.ReplaceRecoveryCodes] cannot replace recovery codes. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]models.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	return tx.Create(&codes).Error
+}
+
+// UseRecoveryCode гасит неиспользованный код восстановления пользователя. Если такого кода нет,
+// возвращает errs.ErrInvalidTwoFactorCode.
+func UseRecoveryCode(userID uint, codeHash string, now time.Time) error {
+	result := db.GetDBConn().Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	if result.Error != nil {
+		logger.Error.Println("[repository.UseRecoveryCode] cannot use recovery code. Error is:", result.Error.Error())
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func CountUnusedRecoveryCodes(userID uint) (count int64, err error) {
+	err = db.GetDBConn().Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error.Println("[repository.CountUnusedRecoveryCodes] cannot count recovery codes. Error is:", err.Error())
+		return 0, translateError(err)
+	}
+	return count, nil
+}
+
+func CreateTwoFactorChallenge(challenge *models.TwoFactorChallenge) error {
+	if err := db.GetDBConn().Create(challenge).Error; err != nil {
+		logger.Error.Println("[repository.CreateTwoFactorChallenge] cannot create two-factor challenge. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+func GetTwoFactorChallengeByHash(tokenHash string) (challenge models.TwoFactorChallenge, err error) {
+	err = db.GetDBConn().Where("token_hash = ?", tokenHash).First(&challenge).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetTwoFactorChallengeByHash] cannot get two-factor challenge. Error is:", err.Error())
+		return challenge, translateError(err)
+	}
+	return challenge, nil
+}
+
+// AddTwoFactorChallengeAttempt засчитывает неверный код; после maxAttempts попыток вход удаляется
+func AddTwoFactorChallengeAttempt(id uint, maxAttempts int) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.TwoFactorChallenge{}).
+			Where("id = ?", id).
+			Update("attempts", gorm.Expr("attempts + 1")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND attempts >= ?", id, maxAttempts).Delete(&models.TwoFactorChallenge{}).Error
+	})
+	if err != nil {
+		logger.Error.Println("[repository.AddTwoFactorChallengeAttempt] cannot count two-factor attempt. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// DeleteTwoFactorChallenge завершает вход. Если вход уже завершён параллельным запросом,
+// возвращает errs.ErrInvalidToken.
+func DeleteTwoFactorChallenge(id uint) error {
+	result := db.GetDBConn().Where("id = ?", id).Delete(&models.TwoFactorChallenge{})
+	if result.Error != nil {
+		logger.Error.Println("[repository.DeleteTwoFactorChallenge] cannot delete two-factor challenge. Error is:", result.Error.Error())
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidToken
+	}
+	return nil
+}
@@ -4,10 +4,19 @@ import (
 	"coinkeeper/db"
 	"coinkeeper/logger"
 	"coinkeeper/models"
+	"gorm.io/gorm"
+	"time"
 )
 
-func CreateUser(user models.User) (err error) {
-	if err = db.GetDBConn().Create(&user).Error; err != nil {
+// CreateUser создаёт пользователя вместе с его категориями по умолчанию
+func CreateUser(user models.User, categories []models.Category) (err error) {
+	err = db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return seedCategories(tx, user.ID, nil, categories)
+	})
+	if err != nil {
 		logger.Error.Println("[repository.CreateUser] cannot create user. Error is:", err.Error())
 		return translateError(err)
 	}
@@ -15,7 +24,7 @@ func CreateUser(user models.User) (err error) {
 }
 
 func GetAllUsers() (users []models.User, err error) {
-	err = db.GetDBConn().Find(&users).Error
+	err = db.GetDBConn().Where("is_deleted = false").Find(&users).Error
 	if err != nil {
 		logger.Error.Println("[repository.GetAllUsers] cannot get all users. Error is:", err.Error())
 		return nil, translateError(err)
@@ -24,7 +33,7 @@ func GetAllUsers() (users []models.User, err error) {
 }
 
 func GetUserByID(id uint) (user models.User, err error) {
-	err = db.GetDBConn().Where("id = ?", id).First(&user).Error
+	err = db.GetDBConn().Where("id = ? AND is_deleted = false", id).First(&user).Error
 	if err != nil {
 		logger.Error.Println("[repository.GetUserByID] cannot get user by id. Error is:", err.Error())
 		return user, translateError(err)
@@ -33,25 +42,43 @@ func GetUserByID(id uint) (user models.User, err error) {
 }
 
 func GetUserByUsername(username string) (user models.User, err error) {
-	err = db.GetDBConn().Where("username = ?", username).First(&user).Error
+	err = db.GetDBConn().Where("username = ? AND is_deleted = false", username).First(&user).Error
 	if err != nil {
-		logger.Error.Println("[repository.GetUserByID] cannot get user by id. Error is:", err.Error())
+		logger.Error.Println("[repository.GetUserByUsername] cannot get user by username. Error is:", err.Error())
 		return user, translateError(err)
 	}
 	return user, nil
 }
 
-func GetUserByUsernameAndPassword(username string, password string) (user models.User, err error) {
-	err = db.GetDBConn().Where("username = ? AND password = ?", username, password).First(&user).Error
+func GetUserByEmail(email string) (user models.User, err error) {
+	err = db.GetDBConn().Where("email = ? AND is_deleted = false", email).First(&user).Error
 	if err != nil {
-		logger.Error.Println("[repository.GetUserByID] cannot get user by id. Error is:", err.Error())
+		logger.Error.Println("[repository.GetUserByEmail] cannot get user by email. Error is:", err.Error())
 		return user, translateError(err)
 	}
 	return user, nil
 }
 
+// UpdateUserPassword заменяет хеш пароля пользователя
+func UpdateUserPassword(userID uint, passwordHash string) error {
+	err := db.GetDBConn().Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("password", passwordHash).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateUserPassword] cannot update user password. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// UpdateUser сохраняет поля, которые меняет администратор: имя, email, базовую валюту и роль.
+// Остальные колонки (пароль, сессии, двухфакторная аутентификация, удаление) не перезаписываются,
+// чтобы не затереть изменения, сделанные другими запросами после чтения user.
 func UpdateUser(user models.User) error {
-	err := db.GetDBConn().Save(&user).Error
+	err := db.GetDBConn().Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Select("full_name", "email", "base_currency", "role").
+		Updates(&user).Error
 	if err != nil {
 		logger.Error.Println("[repository.UpdateUser] cannot update user. Error is:", err.Error())
 		return translateError(err)
@@ -59,14 +86,119 @@ func UpdateUser(user models.User) error {
 	return nil
 }
 
-func DeleteUser(id uint) (err error) {
-	err = db.GetDBConn().
-		Table("users").
-		Where("id = ?", id).
-		Update("is_deleted", true).Error
+// UpdateUserProfile сохраняет только поля профиля: имя, базовую валюту, язык и часовой пояс
+func UpdateUserProfile(user models.User) error {
+	err := db.GetDBConn().Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Select("full_name", "base_currency", "locale", "time_zone").
+		Updates(&user).Error
+	if err != nil {
+		logger.Error.Println("[repository.UpdateUserProfile] cannot update user profile. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// DeleteUser помечает учётную запись удалённой и завершает все её сессии. Данные пользователя
+// удаляются окончательно позже, в PurgeUser.
+func DeleteUser(id uint, now time.Time) error {
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{"is_deleted": true, "deleted_at": now}).Error
+		if err != nil {
+			return err
+		}
+		return revokeAllUserTokens(tx, id, now)
+	})
 	if err != nil {
 		logger.Error.Println("[repository.DeleteUser] cannot delete user. Error is:", err.Error())
-		return err
+		return translateError(err)
+	}
+	return nil
+}
+
+// IsUsernameTaken проверяет, занят ли логин, в том числе удалёнными, но ещё не стёртыми учётными записями
+func IsUsernameTaken(username string) (taken bool, err error) {
+	err = db.GetDBConn().Raw("SELECT EXISTS (SELECT 1 FROM users WHERE username = ?)", username).Scan(&taken).Error
+	if err != nil {
+		logger.Error.Println("[repository.IsUsernameTaken] cannot check username. Error is:", err.Error())
+		return false, translateError(err)
+	}
+	return taken, nil
+}
+
+// IsEmailTaken проверяет, занят ли email другой учётной записью, кроме exceptUserID,
+// в том числе удалённой, но ещё не стёртой
+func IsEmailTaken(email string, exceptUserID uint) (taken bool, err error) {
+	err = db.GetDBConn().Raw("SELECT EXISTS (SELECT 1 FROM users WHERE email = ? AND id <> ?)", email, exceptUserID).
+		Scan(&taken).Error
+	if err != nil {
+		logger.Error.Println("[repository.IsEmailTaken] cannot check email. Error is:", err.Error())
+		return false, translateError(err)
+	}
+	return taken, nil
+}
+
+// GetUsersToPurge возвращает учётные записи, удалённые раньше before
+func GetUsersToPurge(before time.Time) (users []models.User, err error) {
+	err = db.GetDBConn().Where("is_deleted = true AND deleted_at < ?", before).Find(&users).Error
+	if err != nil {
+		logger.Error.Println("[repository.GetUsersToPurge] cannot get users to purge. Error is:", err.Error())
+		return nil, translateError(err)
+	}
+	return users, nil
+}
+
+// userDataDeletes — удаление данных пользователя в порядке, допустимом внешними ключами:
+// сначала строки, ссылающиеся на карты, категории и цели, затем они сами. Параметр @user.
+var userDataDeletes = []string{
+	"DELETE FROM recurring_occurrences WHERE rule_id IN (SELECT id FROM recurring_rules WHERE user_id = @user)",
+	"DELETE FROM transactions WHERE user_id = @user",
+	"DELETE FROM goal_contributions WHERE user_id = @user OR goal_id IN (SELECT id FROM goals WHERE user_id = @user)",
+	"DELETE FROM transfers WHERE user_id = @user",
+	"DELETE FROM goals WHERE user_id = @user",
+	"DELETE FROM budgets WHERE user_id = @user",
+	"DELETE FROM recurring_rules WHERE user_id = @user",
+	"DELETE FROM categories WHERE user_id = @user",
+	"DELETE FROM cards WHERE user_id = @user",
+	"DELETE FROM exchange_rates WHERE user_id = @user",
+	"DELETE FROM refresh_tokens WHERE user_id = @user",
+	"DELETE FROM password_reset_tokens WHERE user_id = @user",
+	"DELETE FROM recovery_codes WHERE user_id = @user",
+	"DELETE FROM two_factor_challenges WHERE user_id = @user",
+	"DELETE FROM login_attempts WHERE user_id = @user OR username = @username",
+	"DELETE FROM login_throttles WHERE key = 'user:' || LOWER(@username)",
+	"DELETE FROM audit_entries WHERE user_id = @user",
+	"DELETE FROM users WHERE id = @user",
+}
+
+// PurgeUser окончательно удаляет учётную запись и все данные пользователя в одной транзакции
+func PurgeUser(user models.User) error {
+	params := map[string]interface{}{"user": user.ID, "username": user.Username}
+	err := db.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		for _, statement := range userDataDeletes {
+			if err := tx.Exec(statement, params).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error.Println("[repository.PurgeUser] cannot purge user. Error is:", err.Error())
+		return translateError(err)
+	}
+	return nil
+}
+
+// SetUsersRole выдаёт роль role пользователям с логинами из usernames
+func SetUsersRole(usernames []string, role string) error {
+	err := db.GetDBConn().Model(&models.User{}).
+		Where("username IN ?", usernames).
+		Update("role", role).Error
+	if err != nil {
+		logger.Error.Println("[repository.SetUsersRole] cannot set users role. Error is:", err.Error())
+		return translateError(err)
 	}
 	return nil
 }
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"testing"
+	"time"
+)
+
+// Изменение профиля по ранее прочитанной записи не затирает пароль, выход из сессий и удаление
+func TestUpdateUserProfileKeepsOtherColumns(t *testing.T) {
+	testdb.Open(t)
+	created := testdb.CreateUser(t, "owner")
+	stale, err := GetUserByID(created.ID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+
+	if err = UpdateUserPassword(created.ID, "new hash"); err != nil {
+		t.Fatalf("UpdateUserPassword: %v", err)
+	}
+	if err = DeleteUser(created.ID, time.Now()); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	stale.FullName = "Owner"
+	stale.TimeZone = "Asia/Dushanbe"
+	if err = UpdateUserProfile(stale); err != nil {
+		t.Fatalf("UpdateUserProfile: %v", err)
+	}
+
+	var user models.User
+	if err = db.GetDBConn().Where("id = ?", created.ID).First(&user).Error; err != nil {
+		t.Fatalf("cannot get user: %v", err)
+	}
+	if user.FullName != "Owner" || user.TimeZone != "Asia/Dushanbe" {
+		t.Errorf("profile = %q, %q, want the updated name and time zone", user.FullName, user.TimeZone)
+	}
+	if user.Password != "new hash" {
+		t.Errorf("password = %q, want the hash set after the profile was read", user.Password)
+	}
+	if !user.IsDeleted || user.TokensRevokedAt == nil {
+		t.Errorf("is_deleted = %v, tokens_revoked_at = %v, want the account to stay deleted", user.IsDeleted, user.TokensRevokedAt)
+	}
+}
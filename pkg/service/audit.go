@@ -0,0 +1,39 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"strings"
+)
+
+// GetAuditEntries возвращает страницу журнала изменений данных пользователя, сначала новые
+func GetAuditEntries(userID uint, filter models.AuditFilter, params models.ListParams) (entries []models.AuditEntry, page models.PageInfo, err error) {
+	filter.EntityType = strings.ToLower(strings.TrimSpace(filter.EntityType))
+	switch filter.EntityType {
+	case "", models.AuditEntityTransaction, models.AuditEntityCard, models.AuditEntityTransfer, models.AuditEntityCategory:
+	default:
+		return nil, page, errs.ErrValidationFailed
+	}
+	filter.Action = strings.ToLower(strings.TrimSpace(filter.Action))
+	switch filter.Action {
+	case "", models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete, models.AuditActionRestore:
+	default:
+		return nil, page, errs.ErrValidationFailed
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, page, errs.ErrValidationFailed
+	}
+	if params, err = normalizeListParams(params, models.SortByDate); err != nil {
+		return nil, page, err
+	}
+
+	entries, total, err := repository.GetAuditEntriesPage(userID, filter, params)
+	if err != nil {
+		return nil, page, err
+	}
+	if entries == nil {
+		entries = []models.AuditEntry{}
+	}
+	return entries, models.NewPageInfo(params, total), nil
+}
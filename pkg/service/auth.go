@@ -1,24 +1,200 @@
 package service
 
 import (
+	"coinkeeper/configs"
 	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
 	"coinkeeper/pkg/repository"
 	"coinkeeper/utils"
 	"errors"
+	"time"
 )
 
-func SignIn(username, password string) (accessToken string, err error) {
-	password = utils.GenerateHash(password)
-	user, err := repository.GetUserByUsernameAndPassword(username, password)
+// dummyPasswordHash сравнивается с паролем, если пользователь не найден, чтобы время ответа
+// не выдавало, существует ли логин
+var dummyPasswordHash, _ = utils.HashPassword("coinkeeper")
+
+// SignIn проверяет пароль и выдаёт пару токенов. Хеш в устаревшем формате (SHA-256)
+// после успешного входа прозрачно пересчитывается в argon2id. Если у пользователя включена
+// двухфакторная аутентификация, вместо токенов возвращается токен второго шага входа.
+// Неудачные входы считаются по логину и IP-адресу client; после порогов из
+// login_protection_params вход временно блокируется и возвращается *LoginLockedError.
+func SignIn(username, password string, client models.LoginClient) (result models.SignInResult, err error) {
+	user, err := repository.GetUserByUsername(username)
+	if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+		return result, err
+	}
+	found := err == nil
+	var userID *uint
+	if found {
+		userID = &user.ID
+	}
+	if err = checkLoginLock(username, userID, client); err != nil {
+		return result, err
+	}
+
+	if !found {
+		_, _, _ = utils.VerifyPassword(password, dummyPasswordHash)
+		if err = registerLoginFailure(username, nil, client, models.LoginFailureInvalidCredentials); err != nil {
+			return result, err
+		}
+		return result, errs.ErrIncorrectUsernameOrPassword
+	}
+
+	ok, needsRehash, err := utils.VerifyPassword(password, user.Password)
+	if err != nil {
+		logger.Error.Printf("[service.SignIn] cannot verify password of user %d. Error is: %s\n", user.ID, err.Error())
+		ok = false
+	}
+	if !ok {
+		if err = registerLoginFailure(username, userID, client, models.LoginFailureInvalidCredentials); err != nil {
+			return result, err
+		}
+		return result, errs.ErrIncorrectUsernameOrPassword
+	}
+	if needsRehash {
+		rehashPassword(user.ID, password)
+	}
+
+	if user.TwoFactorEnabled {
+		return startTwoFactorChallenge(user)
+	}
+	resetLoginFailures(username, client)
+	tokens, err := startSession(user)
+	if err != nil {
+		return result, err
+	}
+	result.TokenPair = &tokens
+	result.TwoFactorSetupRequired = isTwoFactorRequired(user)
+	return result, nil
+}
+
+// RefreshTokens обменивает refresh-токен на новую пару токенов. Старый refresh-токен
+// отзывается; его повторное предъявление отзывает все токены этой сессии.
+func RefreshTokens(refreshToken string) (tokens models.TokenPair, err error) {
+	current, err := repository.GetRefreshTokenByHash(utils.GenerateHash(refreshToken))
 	if err != nil {
 		if errors.Is(err, errs.ErrRecordNotFound) {
-			return "", errs.ErrIncorrectUsernameOrPassword
+			return tokens, errs.ErrInvalidToken
+		}
+		return tokens, err
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		logger.Warn.Printf("[service.RefreshTokens] reuse of revoked refresh token of user %d, revoking session\n", current.UserID)
+		if err = repository.RevokeRefreshTokenFamily(current.UserID, current.FamilyID, now); err != nil {
+			return tokens, err
+		}
+		return tokens, errs.ErrInvalidToken
+	}
+	if !current.ExpiresAt.After(now) {
+		return tokens, errs.ErrInvalidToken
+	}
+
+	user, err := repository.GetUserByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return tokens, errs.ErrInvalidToken
+		}
+		return tokens, err
+	}
+
+	nextToken, next, err := newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return tokens, err
+	}
+	if err = repository.RotateRefreshToken(current.ID, &next, now); err != nil {
+		return tokens, err
+	}
+	return issueTokens(user, nextToken)
+}
+
+// Logout завершает текущую сессию: отзывает access-токен из claims и цепочку refresh-токена
+func Logout(claims *CustomClaims, refreshToken string) error {
+	if refreshToken != "" {
+		current, err := repository.GetRefreshTokenByHash(utils.GenerateHash(refreshToken))
+		if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && current.UserID == claims.UserID {
+			if err = repository.RevokeRefreshTokenFamily(current.UserID, current.FamilyID, time.Now()); err != nil {
+				return err
+			}
 		}
-		return "", err
 	}
-	accessToken, err = GenerateToken(user.ID, user.Username, user.Password)
+	return repository.RevokeAccessToken(claims.Id, time.Unix(claims.ExpiresAt, 0))
+}
+
+// LogoutAll завершает все сессии пользователя на всех устройствах
+func LogoutAll(userID uint) error {
+	// Токены несут время выдачи с точностью до секунды; токен, выданный в ту же секунду
+	// сразу после выхода, должен остаться действительным
+	return repository.RevokeAllUserTokens(userID, time.Now().Truncate(time.Second))
+}
+
+// PurgeExpiredTokens удаляет записи о токенах, срок которых истёк
+func PurgeExpiredTokens(now time.Time) (int64, error) {
+	return repository.PurgeExpiredTokens(now)
+}
+
+// rehashPassword сохраняет пароль в текущем формате; ошибка не мешает входу,
+// пересчёт повторится при следующем входе
+func rehashPassword(userID uint, password string) {
+	hash, err := utils.HashPassword(password)
+	if err == nil {
+		err = repository.UpdateUserPassword(userID, hash)
+	}
 	if err != nil {
-		return "", err
+		logger.Error.Printf("[service.rehashPassword] cannot rehash password of user %d. Error is: %s\n", userID, err.Error())
+	}
+}
+
+// startSession начинает новую сессию пользователя: новую цепочку refresh-токенов и пару токенов
+func startSession(user models.User) (tokens models.TokenPair, err error) {
+	familyID, err := utils.RandomID(16)
+	if err != nil {
+		return tokens, err
+	}
+	refreshToken, record, err := newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return tokens, err
+	}
+	if err = repository.CreateRefreshToken(&record); err != nil {
+		return tokens, err
+	}
+	return issueTokens(user, refreshToken)
+}
+
+func issueTokens(user models.User, refreshToken string) (tokens models.TokenPair, err error) {
+	tokens.AccessToken, err = GenerateToken(user)
+	if err != nil {
+		return tokens, err
+	}
+	tokens.RefreshToken = refreshToken
+	tokens.ExpiresIn = int64(accessTokenTTL() / time.Second)
+	return tokens, nil
+}
+
+// newRefreshToken создаёт случайный refresh-токен и запись о нём в цепочке familyID
+func newRefreshToken(userID uint, familyID string) (string, models.RefreshToken, error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return "", models.RefreshToken{}, err
+	}
+	return token, models.RefreshToken{
+		TokenHash: utils.GenerateHash(token),
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL()),
+	}, nil
+}
+
+// refreshTokenTTL — время жизни refresh-токена из настроек, по умолчанию 30 дней
+func refreshTokenTTL() time.Duration {
+	if hours := configs.AppSettings.AuthParams.RefreshTtlHours; hours > 0 {
+		return time.Duration(hours) * time.Hour
 	}
-	return accessToken, nil
+	return 30 * 24 * time.Hour
 }
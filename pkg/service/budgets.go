@@ -0,0 +1,178 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"math"
+	"time"
+)
+
+// budgetNearLimitPercentage — доля потраченного лимита, начиная с которой бюджет считается почти исчерпанным
+const budgetNearLimitPercentage = 80
+
+func GetAllBudgets(userID uint, year, month int) (budgets []models.Budget, err error) {
+	budgets, err = repository.GetAllBudgets(userID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func GetBudgetByID(userID, budgetID uint) (budget models.Budget, err error) {
+	if err = authorizeBudget(userID, budgetID); err != nil {
+		return models.Budget{}, err
+	}
+	budget, err = repository.GetBudgetByID(userID, budgetID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return budget, errs.ErrOperationNotFound
+		}
+		return budget, err
+	}
+	return budget, nil
+}
+
+func CreateBudget(budget models.Budget) error {
+	if err := validateBudget(&budget); err != nil {
+		return err
+	}
+
+	_, err := repository.GetBudgetByPeriod(budget.UserID, budget.CategoryID, budget.Year, budget.Month)
+	if err == nil {
+		return errs.ErrBudgetAlreadyExists
+	}
+	if !errors.Is(err, errs.ErrRecordNotFound) {
+		return err
+	}
+
+	return repository.CreateBudget(budget)
+}
+
+func UpdateBudget(budget models.Budget) error {
+	if _, err := GetBudgetByID(budget.UserID, budget.ID); err != nil {
+		return err
+	}
+	if err := validateBudget(&budget); err != nil {
+		return err
+	}
+
+	existing, err := repository.GetBudgetByPeriod(budget.UserID, budget.CategoryID, budget.Year, budget.Month)
+	if err == nil && existing.ID != budget.ID {
+		return errs.ErrBudgetAlreadyExists
+	}
+	if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+		return err
+	}
+
+	return repository.UpdateBudget(budget)
+}
+
+func DeleteBudget(budgetID, userID uint) error {
+	if _, err := GetBudgetByID(userID, budgetID); err != nil {
+		return err
+	}
+	return repository.DeleteBudget(budgetID, userID)
+}
+
+func validateBudget(budget *models.Budget) (err error) {
+	if budget.Year < 1 {
+		return errs.NewFieldError("year", errs.FieldTooSmall, "must be at least 1")
+	}
+	if budget.Month < 1 || budget.Month > 12 {
+		return errs.NewFieldError("month", errs.FieldInvalid, "must be between 1 and 12")
+	}
+	if budget.Limit.Minor <= 0 {
+		return errs.NewFieldError("limit", errs.FieldTooSmall, "must be greater than 0")
+	}
+	if budget.Limit.Currency == "" {
+		currency, err := resolveReportCurrency(budget.UserID, "")
+		if err != nil {
+			return err
+		}
+		if err = inCurrency(&budget.Limit, currency, "limit"); err != nil {
+			return err
+		}
+	}
+	if budget.Limit.Currency, err = normalizeCurrency(budget.Limit.Currency); err != nil {
+		return errs.NewFieldError("limit", errs.FieldInvalid, "has an unsupported currency")
+	}
+
+	if err = checkCategory(budget.UserID, budget.CategoryID, models.CategoryTypeOutcome); err != nil {
+		if errors.Is(err, errs.ErrValidationFailed) {
+			return errs.NewFieldError("category_id", errs.FieldNotFound, "outcome category not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetBudgetStatus считает потраченное по бюджету за его месяц в валюте лимита
+func GetBudgetStatus(userID, budgetID uint) (status models.BudgetStatus, err error) {
+	budget, err := GetBudgetByID(userID, budgetID)
+	if err != nil {
+		return status, err
+	}
+	location, err := GetUserLocation(userID)
+	if err != nil {
+		return status, err
+	}
+	return budgetStatus(budget, location)
+}
+
+// GetBudgetsStatus возвращает состояние всех бюджетов пользователя за месяц
+func GetBudgetsStatus(userID uint, year, month int) ([]models.BudgetStatus, error) {
+	budgets, err := GetAllBudgets(userID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := GetUserLocation(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]models.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := budgetStatus(budget, location)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// budgetStatus считает потраченное за месяц бюджета; месяц операции определяется в зоне
+// пользователя location — так же, как периоды отчётов
+func budgetStatus(budget models.Budget, location *time.Location) (status models.BudgetStatus, err error) {
+	spending, err := repository.GetCategorySpending(budget.UserID, budget.CategoryID, budget.Year, budget.Month, timeZoneName(location))
+	if err != nil {
+		return status, err
+	}
+
+	spent := models.NewMoney(0, budget.Limit.Currency)
+	for _, amount := range spending {
+		converted, err := ConvertMoney(budget.UserID, amount, budget.Limit.Currency)
+		if err != nil {
+			return status, err
+		}
+		spent.Minor += converted.Minor
+	}
+
+	status.Budget = budget
+	status.Spent = spent
+	status.Remaining = models.NewMoney(budget.Limit.Minor-spent.Minor, budget.Limit.Currency)
+	status.Percentage = math.Round(float64(spent.Minor)*10000/float64(budget.Limit.Minor)) / 100
+
+	switch {
+	case spent.Minor > budget.Limit.Minor:
+		status.Status = models.BudgetStatusOverspent
+	case status.Percentage >= budgetNearLimitPercentage:
+		status.Status = models.BudgetStatusNearLimit
+	default:
+		status.Status = models.BudgetStatusWithin
+	}
+	return status, nil
+}
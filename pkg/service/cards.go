@@ -5,21 +5,42 @@ import (
 	"coinkeeper/models"
 	"coinkeeper/pkg/repository"
 	"errors"
-	"gorm.io/gorm"
 )
 
-func GetAllCards(userID uint) (cards []models.Card, err error) {
-	cards, err = repository.GetAllCards(userID)
+// GetAllCards возвращает страницу карт, подходящих под filter, и метаданные страницы
+func GetAllCards(userID uint, filter models.CardFilter, params models.ListParams) (cards []models.Card, page models.PageInfo, err error) {
+	if filter.Currency != "" {
+		if filter.Currency, err = normalizeCurrency(filter.Currency); err != nil {
+			return nil, page, err
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, page, errs.ErrValidationFailed
+	}
+	if filter.MinBalance != nil && filter.MaxBalance != nil && *filter.MinBalance > *filter.MaxBalance {
+		return nil, page, errs.ErrValidationFailed
+	}
+	if params, err = normalizeListParams(params, models.SortByDate, models.SortByAmount); err != nil {
+		return nil, page, err
+	}
+
+	cards, total, err := repository.GetCardsPage(userID, filter, params)
 	if err != nil {
-		return nil, err
+		return nil, page, err
+	}
+	if cards == nil {
+		cards = []models.Card{}
 	}
-	return cards, nil
+	return cards, models.NewPageInfo(params, total), nil
 }
 
 func GetCardByID(userID, cardID uint) (card models.Card, err error) {
+	if err = authorizeCard(userID, cardID); err != nil {
+		return models.Card{}, err
+	}
 	card, err = repository.GetCardByID(userID, cardID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, errs.ErrRecordNotFound) {
 			return card, errs.ErrOperationNotFound
 		}
 		return models.Card{}, err
@@ -27,23 +48,93 @@ func GetCardByID(userID, cardID uint) (card models.Card, err error) {
 	return card, nil
 }
 
-func CreateCard(card models.Card) error {
-	if err := repository.CreateCard(card); err != nil {
+// getActiveCard возвращает карту пользователя, по которой проводится операция: карта
+// в корзине — ErrCardDeleted, несуществующая — ErrOperationNotFound
+func getActiveCard(userID, cardID uint) (models.Card, error) {
+	if err := authorizeCard(userID, cardID); err != nil {
+		return models.Card{}, err
+	}
+	card, err := repository.GetCardByID(userID, cardID)
+	if errors.Is(err, errs.ErrRecordNotFound) {
+		// Владелец у карты есть, значит она удалена
+		return models.Card{}, errs.ErrCardDeleted
+	}
+	return card, err
+}
+
+func CreateCard(card models.Card, meta models.AuditMeta) error {
+	if err := inCurrency(&card.Balance, models.DefaultCurrency, "balance"); err != nil {
+		return err
+	}
+	currency, err := normalizeCurrency(card.Balance.Currency)
+	if err != nil {
+		return errs.NewFieldError("balance", errs.FieldInvalid, "has an unsupported currency")
+	}
+	card.Balance.Currency = currency
+
+	if err := repository.CreateCard(card, meta); err != nil {
 		return err
 	}
 	return nil
 }
 
-func UpdateCardBalance(cardID uint, amount float32) error {
-	if err := repository.UpdateCardBalance(cardID, amount); err != nil {
+// UpdateCardBalance изменяет баланс карты пользователя на amount; сумма без валюты считается в валюте карты
+func UpdateCardBalance(userID, cardID uint, amount models.Money, meta models.AuditMeta) error {
+	card, err := GetCardByID(userID, cardID)
+	if err != nil {
+		return err
+	}
+	if err = inCurrency(&amount, card.Balance.Currency, "amount"); err != nil {
+		return err
+	}
+	if err = repository.UpdateCardBalance(cardID, userID, amount, meta); err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
 		return err
 	}
 	return nil
 }
 
-func DeleteCard(cardID, userID uint) error {
-	if err := repository.DeleteCard(cardID, userID); err != nil {
+func DeleteCard(cardID, userID uint, meta models.AuditMeta) error {
+	if err := authorizeCard(userID, cardID); err != nil {
+		return err
+	}
+	if err := repository.DeleteCard(cardID, userID, meta); err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
 		return err
 	}
 	return nil
 }
+
+// GetCardsBalance возвращает балансы карт пользователя и их сумму в валюте currency
+// (по умолчанию — в базовой валюте пользователя)
+func GetCardsBalance(userID uint, currency string) (balance models.CardsBalance, err error) {
+	balance.Currency, err = resolveReportCurrency(userID, currency)
+	if err != nil {
+		return balance, err
+	}
+	balance.Total = models.NewMoney(0, balance.Currency)
+	balance.Cards = []models.CardBalance{}
+
+	cards, err := repository.GetAllCards(userID)
+	if err != nil {
+		return balance, err
+	}
+	for _, card := range cards {
+		converted, err := ConvertMoney(userID, card.Balance, balance.Currency)
+		if err != nil {
+			return balance, err
+		}
+		balance.Total.Minor += converted.Minor
+		balance.Cards = append(balance.Cards, models.CardBalance{
+			CardID:     card.ID,
+			CardNumber: card.CardNumber,
+			Balance:    card.Balance,
+			Converted:  converted,
+		})
+	}
+	return balance, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// defaultCategories создаются каждому новому пользователю при регистрации
+var defaultCategories = []models.Category{
+	{Title: "Food", Type: models.CategoryTypeOutcome, Icon: "restaurant", Color: "#FF9800", Children: []models.Category{
+		{Title: "Groceries", Type: models.CategoryTypeOutcome, Icon: "cart", Color: "#FF9800"},
+		{Title: "Cafes and restaurants", Type: models.CategoryTypeOutcome, Icon: "coffee", Color: "#FF9800"},
+	}},
+	{Title: "Transport", Type: models.CategoryTypeOutcome, Icon: "bus", Color: "#2196F3", Children: []models.Category{
+		{Title: "Public transport", Type: models.CategoryTypeOutcome, Icon: "bus", Color: "#2196F3"},
+		{Title: "Taxi", Type: models.CategoryTypeOutcome, Icon: "taxi", Color: "#2196F3"},
+		{Title: "Fuel", Type: models.CategoryTypeOutcome, Icon: "fuel", Color: "#2196F3"},
+	}},
+	{Title: "Housing", Type: models.CategoryTypeOutcome, Icon: "home", Color: "#795548", Children: []models.Category{
+		{Title: "Rent", Type: models.CategoryTypeOutcome, Icon: "key", Color: "#795548"},
+		{Title: "Utilities", Type: models.CategoryTypeOutcome, Icon: "bolt", Color: "#795548"},
+	}},
+	{Title: "Health", Type: models.CategoryTypeOutcome, Icon: "heart", Color: "#F44336"},
+	{Title: "Clothing", Type: models.CategoryTypeOutcome, Icon: "shirt", Color: "#9C27B0"},
+	{Title: "Entertainment", Type: models.CategoryTypeOutcome, Icon: "film", Color: "#E91E63"},
+	{Title: "Phone and internet", Type: models.CategoryTypeOutcome, Icon: "phone", Color: "#607D8B"},
+	{Title: "Other expenses", Type: models.CategoryTypeOutcome, Icon: "dots", Color: "#9E9E9E"},
+	{Title: "Salary", Type: models.CategoryTypeIncome, Icon: "briefcase", Color: "#4CAF50"},
+	{Title: "Gifts", Type: models.CategoryTypeIncome, Icon: "gift", Color: "#8BC34A"},
+	{Title: "Interest", Type: models.CategoryTypeIncome, Icon: "percent", Color: "#009688"},
+	{Title: "Other income", Type: models.CategoryTypeIncome, Icon: "dots", Color: "#9E9E9E"},
+}
+
+var categoryColorRegexp = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// GetAllCategories возвращает категории пользователя и общие категории типа categoryType
+// (все типы, если он пуст). При tree = true категории вкладываются в родителей через Children.
+func GetAllCategories(userID uint, categoryType string, tree bool) (categories []models.Category, err error) {
+	categoryType = strings.ToLower(strings.TrimSpace(categoryType))
+	if categoryType != "" && categoryType != models.CategoryTypeIncome && categoryType != models.CategoryTypeOutcome {
+		return nil, errs.ErrValidationFailed
+	}
+
+	categories, err = repository.GetAllCategories(userID, categoryType)
+	if err != nil {
+		return nil, err
+	}
+	if tree {
+		return buildCategoryTree(categories), nil
+	}
+	return categories, nil
+}
+
+// buildCategoryTree раскладывает плоский список по родителям. Категории, чей родитель
+// не попал в список, остаются на верхнем уровне.
+func buildCategoryTree(categories []models.Category) []models.Category {
+	present := make(map[uint]bool, len(categories))
+	children := map[uint][]models.Category{}
+	for _, category := range categories {
+		present[category.ID] = true
+	}
+	for _, category := range categories {
+		if category.ParentID != nil && present[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var attach func(category models.Category) models.Category
+	attach = func(category models.Category) models.Category {
+		for _, child := range children[category.ID] {
+			category.Children = append(category.Children, attach(child))
+		}
+		return category
+	}
+
+	roots := []models.Category{}
+	for _, category := range categories {
+		if category.ParentID == nil || !present[*category.ParentID] {
+			roots = append(roots, attach(category))
+		}
+	}
+	return roots
+}
+
+func GetCategoryByID(userID, categoryID uint) (category models.Category, err error) {
+	category, err = repository.GetCategoryByID(userID, categoryID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return category, errs.ErrOperationNotFound
+		}
+		return category, err
+	}
+	return category, nil
+}
+
+func CreateCategory(category models.Category) error {
+	category.Type = strings.ToLower(strings.TrimSpace(category.Type))
+	if category.Type == "" {
+		category.Type = models.CategoryTypeOutcome
+	}
+	if err := validateCategory(category); err != nil {
+		return err
+	}
+	return repository.CreateCategory(category)
+}
+
+// UpdateCategory меняет название, родителя, иконку и цвет. Тип категории не меняется,
+// общие категории изменять нельзя.
+func UpdateCategory(category models.Category) error {
+	existing, err := getOwnCategory(*category.UserID, category.ID)
+	if err != nil {
+		return err
+	}
+
+	category.Type = existing.Type
+	if err = validateCategory(category); err != nil {
+		return err
+	}
+	if category.ParentID != nil {
+		// Нельзя сделать категорию вложенной в саму себя или в собственного потомка
+		cycle, err := repository.IsCategoryDescendant(*category.ParentID, category.ID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return errs.NewFieldError("parent_id", errs.FieldInvalid, "must not be the category itself or its descendant")
+		}
+	}
+	return repository.UpdateCategory(category)
+}
+
+func DeleteCategory(categoryID, userID uint) error {
+	if _, err := getOwnCategory(userID, categoryID); err != nil {
+		return err
+	}
+	return repository.DeleteCategory(categoryID, userID)
+}
+
+// MergeCategory переносит операции, бюджеты, регулярные правила и вложенные категории
+// из sourceID в targetID и удаляет sourceID. Объединять можно только категории одного типа.
+func MergeCategory(userID, sourceID, targetID uint, meta models.AuditMeta) error {
+	if sourceID == targetID {
+		return errs.NewFieldError("target_id", errs.FieldInvalid, "must differ from the merged category")
+	}
+	source, err := getOwnCategory(userID, sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := GetCategoryByID(userID, targetID)
+	if err != nil {
+		return err
+	}
+	if source.Type != target.Type {
+		return errs.NewFieldError("target_id", errs.FieldInvalid, "must be a "+source.Type+" category")
+	}
+	return repository.MergeCategories(userID, sourceID, target, meta)
+}
+
+// getOwnCategory возвращает категорию, которую пользователь может изменять: общие категории
+// доступны только для чтения
+func getOwnCategory(userID, categoryID uint) (models.Category, error) {
+	category, err := GetCategoryByID(userID, categoryID)
+	if err != nil {
+		return category, err
+	}
+	if category.UserID == nil {
+		return category, errs.ErrPermissionDenied
+	}
+	return category, nil
+}
+
+func validateCategory(category models.Category) error {
+	if strings.TrimSpace(category.Title) == "" {
+		return errs.NewFieldError("title", errs.FieldRequired, "is required")
+	}
+	if category.Type != models.CategoryTypeIncome && category.Type != models.CategoryTypeOutcome {
+		return errs.NewFieldError("type", errs.FieldInvalid, "must be one of: income, outcome")
+	}
+	if category.Color != "" && !categoryColorRegexp.MatchString(category.Color) {
+		return errs.NewFieldError("color", errs.FieldInvalid, "must be a hex color like #4CAF50")
+	}
+	if category.ParentID != nil {
+		if err := checkCategory(*category.UserID, *category.ParentID, category.Type); err != nil {
+			if errors.Is(err, errs.ErrValidationFailed) {
+				return errs.NewFieldError("parent_id", errs.FieldNotFound, category.Type+" category not found")
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCategory проверяет, что категория доступна пользователю и имеет тип categoryType
+func checkCategory(userID, categoryID uint, categoryType string) error {
+	category, err := repository.GetCategoryByID(userID, categoryID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrValidationFailed
+		}
+		return err
+	}
+	if category.Type != categoryType {
+		return errs.ErrValidationFailed
+	}
+	return nil
+}
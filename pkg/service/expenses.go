@@ -3,46 +3,71 @@ package service
 import (
 	"coinkeeper/errs"
 	"coinkeeper/models"
-	"coinkeeper/pkg/repository"
-	"errors"
 )
 
-func GetAllExpenses(userID uint) (expenses []models.Expense, err error) {
-	expenses, err = repository.GetAllExpenses(userID)
+// Траты /api/expenses — представление операций типа expense с картой
+
+func GetAllExpenses(userID uint, filter models.TransactionFilter, params models.ListParams) (expenses []models.Expense, page models.PageInfo, err error) {
+	hasCard := true
+	filter.Type = models.TransactionTypeExpense
+	filter.HasCard = &hasCard
+	transactions, page, err := GetAllTransactions(userID, filter, params)
 	if err != nil {
-		return nil, err
+		return nil, page, err
+	}
+
+	expenses = make([]models.Expense, 0, len(transactions))
+	for _, t := range transactions {
+		expenses = append(expenses, models.ExpenseFromTransaction(t))
 	}
-	return expenses, nil
+	return expenses, page, nil
 }
 
 func GetExpenseByID(userID, expenseID uint) (expense models.Expense, err error) {
-	expense, err = repository.GetExpenseByID(userID, expenseID)
+	transaction, err := getExpenseTransaction(userID, expenseID)
 	if err != nil {
-		if errors.Is(err, errs.ErrRecordNotFound) {
-			return expense, errs.ErrOperationNotFound
-		}
 		return models.Expense{}, err
 	}
-	return expense, nil
+	return models.ExpenseFromTransaction(transaction), nil
 }
 
-func CreateExpense(expense models.Expense) error {
-	if err := repository.CreateExpense(expense); err != nil {
-		return err
+func CreateExpense(expense models.Expense, meta models.AuditMeta) error {
+	if expense.CardID == 0 {
+		return errs.NewFieldError("card_id", errs.FieldRequired, "is required for expenses")
 	}
-	return nil
+	transaction := expense.Transaction()
+	return CreateTransaction(&transaction, meta)
 }
 
-func UpdateExpense(expense models.Expense) error {
-	if err := repository.UpdateExpense(expense); err != nil {
+func UpdateExpense(expense models.Expense, meta models.AuditMeta) error {
+	if expense.CardID == 0 {
+		return errs.NewFieldError("card_id", errs.FieldRequired, "is required for expenses")
+	}
+	existing, err := getExpenseTransaction(expense.UserID, expense.ID)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	transaction := expense.Transaction()
+	transaction.Tags = existing.Tags
+	return UpdateTransaction(transaction, meta)
 }
 
-func DeleteExpense(expenseID uint, userID uint) error {
-	if err := repository.DeleteExpense(expenseID, userID); err != nil {
+func DeleteExpense(expenseID uint, userID uint, meta models.AuditMeta) error {
+	if _, err := getExpenseTransaction(userID, expenseID); err != nil {
 		return err
 	}
-	return nil
+	return DeleteTransaction(expenseID, userID, meta)
+}
+
+// getExpenseTransaction возвращает операцию, только если это трата по карте
+func getExpenseTransaction(userID, expenseID uint) (models.Transaction, error) {
+	transaction, err := GetTransactionByID(userID, expenseID)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+	if transaction.Type != models.TransactionTypeExpense || transaction.CardID == nil {
+		return models.Transaction{}, errs.ErrOperationNotFound
+	}
+	return transaction, nil
 }
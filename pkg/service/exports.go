@@ -0,0 +1,112 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/exporter"
+	"coinkeeper/pkg/repository"
+	"strings"
+	"time"
+)
+
+var (
+	exportTransactionColumns = []string{"id", "type", "date", "time_zone", "description", "amount", "currency", "card_id", "category_id", "transfer_id", "tags"}
+	exportCardColumns        = []string{"id", "card_number", "description", "balance", "currency", "created_at"}
+	exportCategoryColumns    = []string{"id", "title", "type", "parent_id", "icon", "color"}
+)
+
+// ResolveExportDatasets разбирает список наборов данных через запятую; пустой список — все наборы.
+// Наборы возвращаются в порядке models.ExportDatasets без повторов.
+func ResolveExportDatasets(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return models.ExportDatasets, nil
+	}
+
+	requested := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		requested[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var datasets []string
+	for _, name := range models.ExportDatasets {
+		if requested[name] {
+			datasets = append(datasets, name)
+			delete(requested, name)
+		}
+	}
+	if len(requested) > 0 {
+		return nil, errs.ErrValidationFailed
+	}
+	return datasets, nil
+}
+
+// Export потоково записывает в w наборы данных пользователя. Операции ограничиваются
+// периодом [from, to) по дате операции, нулевая граница не ограничивает; карты и категории
+// выгружаются целиком.
+func Export(userID uint, from, to time.Time, datasets []string, w exporter.Writer) error {
+	for _, dataset := range datasets {
+		var err error
+		switch dataset {
+		case models.ExportDatasetTransactions:
+			err = exportTransactions(userID, from, to, w)
+		case models.ExportDatasetCards:
+			err = exportCards(userID, w)
+		case models.ExportDatasetCategories:
+			err = exportCategories(userID, w)
+		default:
+			err = errs.ErrValidationFailed
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func exportTransactions(userID uint, from, to time.Time, w exporter.Writer) error {
+	if err := w.BeginSection(models.ExportDatasetTransactions, exportTransactionColumns); err != nil {
+		return err
+	}
+	return repository.ExportTransactions(userID, from, to, func(batch []models.Transaction) error {
+		for _, t := range batch {
+			localizeTransactionDate(&t)
+			err := w.WriteRow(t.ID, t.Type, t.Date, t.TimeZone, t.Description, exporter.Number(t.Amount.Decimal()), t.Amount.Currency,
+				t.CardID, t.CategoryID, t.TransferID, strings.Join(t.Tags, ","))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func exportCards(userID uint, w exporter.Writer) error {
+	if err := w.BeginSection(models.ExportDatasetCards, exportCardColumns); err != nil {
+		return err
+	}
+	return repository.ExportCards(userID, func(batch []models.Card) error {
+		for _, card := range batch {
+			err := w.WriteRow(card.ID, card.CardNumber, card.Description,
+				exporter.Number(card.Balance.Decimal()), card.Balance.Currency, card.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func exportCategories(userID uint, w exporter.Writer) error {
+	if err := w.BeginSection(models.ExportDatasetCategories, exportCategoryColumns); err != nil {
+		return err
+	}
+	return repository.ExportCategories(userID, func(batch []models.Category) error {
+		for _, category := range batch {
+			err := w.WriteRow(category.ID, category.Title, category.Type, category.ParentID, category.Icon, category.Color)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
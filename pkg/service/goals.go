@@ -0,0 +1,209 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// goalProjectionHorizon — дальше этого срока прогноз достижения цели не строится
+const goalProjectionHorizon = 100 * 365 * 24 * time.Hour
+
+func GetAllGoals(userID uint) (goals []models.Goal, err error) {
+	goals, err = repository.GetAllGoals(userID)
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func GetGoalByID(userID, goalID uint) (goal models.Goal, err error) {
+	if err = authorizeGoal(userID, goalID); err != nil {
+		return models.Goal{}, err
+	}
+	goal, err = repository.GetGoalByID(userID, goalID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return goal, errs.ErrOperationNotFound
+		}
+		return goal, err
+	}
+	return goal, nil
+}
+
+func CreateGoal(goal models.Goal) (err error) {
+	if err = validateGoal(&goal); err != nil {
+		return err
+	}
+
+	// Цель с картой копится в валюте карты
+	if goal.CardID != nil {
+		card, err := GetCardByID(goal.UserID, *goal.CardID)
+		if err != nil {
+			return err
+		}
+		if err = inCurrency(&goal.Target, card.Balance.Currency, "target"); err != nil {
+			return err
+		}
+		if goal.Target.Currency != card.Balance.Currency {
+			return errs.WithField(errs.ErrCurrencyMismatch, "target", errs.FieldInvalid, "must be in the card currency "+card.Balance.Currency)
+		}
+	}
+	if goal.Target.Currency == "" {
+		currency, err := resolveReportCurrency(goal.UserID, "")
+		if err != nil {
+			return err
+		}
+		if err = inCurrency(&goal.Target, currency, "target"); err != nil {
+			return err
+		}
+	}
+	if goal.Target.Currency, err = normalizeCurrency(goal.Target.Currency); err != nil {
+		return errs.NewFieldError("target", errs.FieldInvalid, "has an unsupported currency")
+	}
+
+	goal.ID = 0
+	goal.Saved = models.NewMoney(0, goal.Target.Currency)
+	return repository.CreateGoal(goal)
+}
+
+// UpdateGoal меняет описание, целевую сумму и срок. Валюта и карта цели не меняются.
+func UpdateGoal(goal models.Goal) error {
+	existing, err := GetGoalByID(goal.UserID, goal.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = validateGoal(&goal); err != nil {
+		return err
+	}
+	if err = inCurrency(&goal.Target, existing.Target.Currency, "target"); err != nil {
+		return err
+	}
+	if goal.Target.Currency != existing.Target.Currency {
+		return errs.WithField(errs.ErrCurrencyMismatch, "target", errs.FieldInvalid, "must be in the goal currency "+existing.Target.Currency)
+	}
+	return repository.UpdateGoal(goal)
+}
+
+// validateGoal проверяет название и целевую сумму цели
+func validateGoal(goal *models.Goal) error {
+	goal.Title = strings.TrimSpace(goal.Title)
+	if goal.Title == "" {
+		return errs.NewFieldError("title", errs.FieldRequired, "is required")
+	}
+	if goal.Target.Minor <= 0 {
+		return errs.NewFieldError("target", errs.FieldTooSmall, "must be greater than 0")
+	}
+	return nil
+}
+
+func DeleteGoal(goalID, userID uint) error {
+	if _, err := GetGoalByID(userID, goalID); err != nil {
+		return err
+	}
+	return repository.DeleteGoal(goalID, userID)
+}
+
+func GetGoalContributions(userID, goalID uint) ([]models.GoalContribution, error) {
+	if _, err := GetGoalByID(userID, goalID); err != nil {
+		return nil, err
+	}
+	return repository.GetGoalContributions(userID, goalID)
+}
+
+// ContributeToGoal пополняет цель: сумма списывается с карты-источника (если указана)
+// и пересчитывается в валюту цели
+func ContributeToGoal(contribution models.GoalContribution, meta models.AuditMeta) error {
+	goal, err := GetGoalByID(contribution.UserID, contribution.GoalID)
+	if err != nil {
+		return err
+	}
+	if contribution.Amount.Minor <= 0 {
+		return errs.NewFieldError("amount", errs.FieldTooSmall, "must be greater than 0")
+	}
+
+	if contribution.CardID != nil {
+		card, err := GetCardByID(contribution.UserID, *contribution.CardID)
+		if err != nil {
+			return err
+		}
+		if err = inCurrency(&contribution.Amount, card.Balance.Currency, "amount"); err != nil {
+			return err
+		}
+	}
+	if err = inCurrency(&contribution.Amount, goal.Target.Currency, "amount"); err != nil {
+		return err
+	}
+
+	contribution.Credited, err = ConvertMoney(contribution.UserID, contribution.Amount, goal.Target.Currency)
+	if err != nil {
+		return err
+	}
+
+	contribution.ID = 0
+	return repository.CreateGoalContribution(contribution, meta)
+}
+
+// GetGoalProgress считает процент выполнения, необходимый ежемесячный взнос до срока
+// и прогнозируемую дату достижения по среднему темпу накоплений
+func GetGoalProgress(userID, goalID uint) (progress models.GoalProgress, err error) {
+	goal, err := GetGoalByID(userID, goalID)
+	if err != nil {
+		return progress, err
+	}
+
+	now := time.Now()
+	currency := goal.Target.Currency
+	remaining := goal.Target.Minor - goal.Saved.Minor
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	progress.Goal = goal
+	progress.Completed = remaining == 0
+	progress.Remaining = models.NewMoney(remaining, currency)
+	progress.Percentage = math.Min(100, math.Round(float64(goal.Saved.Minor)*10000/float64(goal.Target.Minor))/100)
+	progress.RequiredMonthly = models.NewMoney(0, currency)
+
+	if progress.Completed {
+		progress.ProjectedCompletion = &now
+		return progress, nil
+	}
+
+	if goal.Deadline != nil {
+		months := monthsBetween(now, *goal.Deadline)
+		if months < 1 {
+			months = 1
+		}
+		progress.RequiredMonthly.Minor = (remaining + int64(months) - 1) / int64(months)
+	}
+
+	// Темп накоплений — накопленное, делённое на время с момента создания цели
+	elapsed := now.Sub(goal.CreatedAt)
+	if goal.Saved.Minor > 0 && elapsed > 0 {
+		perSecond := float64(goal.Saved.Minor) / elapsed.Seconds()
+		seconds := float64(remaining) / perSecond
+		if seconds < goalProjectionHorizon.Seconds() {
+			projected := now.Add(time.Duration(seconds) * time.Second)
+			progress.ProjectedCompletion = &projected
+		}
+	}
+	return progress, nil
+}
+
+// monthsBetween возвращает число целых и неполных месяцев от from до to
+func monthsBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if from.AddDate(0, months, 0).Before(to) {
+		months++
+	}
+	return months
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/importer"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ImportOptions — параметры импорта выписки, выбранные пользователем
+type ImportOptions struct {
+	UserID uint
+	Format string
+	// CardID — карта, к которой относятся операции выписки. Без карты операции не меняют балансов.
+	CardID *uint
+	// CategoryID — категория для списаний, у которых категория не указана в самой выписке
+	CategoryID *uint
+	Currency   string
+	Mapping    models.CSVMapping
+	// Audit — кто выполняет импорт, для журнала изменений
+	Audit models.AuditMeta
+}
+
+// PreviewImport разбирает выписку и показывает, что будет создано, не сохраняя операций
+func PreviewImport(options ImportOptions, r io.Reader) (models.ImportReport, error) {
+	return importStatement(options, r, false)
+}
+
+// ImportStatement разбирает выписку и создаёт операции для новых строк.
+// Дубликаты и строки с ошибками пропускаются и перечисляются в отчёте.
+func ImportStatement(options ImportOptions, r io.Reader) (models.ImportReport, error) {
+	return importStatement(options, r, true)
+}
+
+func importStatement(options ImportOptions, r io.Reader, commit bool) (models.ImportReport, error) {
+	report := models.ImportReport{Format: strings.ToLower(options.Format)}
+
+	currency, err := resolveImportCurrency(options)
+	if err != nil {
+		return report, err
+	}
+
+	rows, err := importer.Parse(report.Format, r, importer.Options{Currency: currency, Mapping: options.Mapping})
+	if err != nil {
+		if errors.Is(err, importer.ErrUnknownFormat) || errors.Is(err, importer.ErrInvalidMapping) {
+			return report, fmt.Errorf("%w: %s", errs.ErrValidationFailed, err.Error())
+		}
+		return report, fmt.Errorf("%w: %s", errs.ErrInvalidStatement, err.Error())
+	}
+
+	categories := map[importCategoryKey]error{}
+	// Одинаковые строки в одной выписке — обычно разные покупки, поэтому строка считается
+	// дубликатом, только если в базе уже есть не меньше совпадающих операций, чем встретилось в файле
+	occurrences := map[string]int64{}
+	for i := range rows {
+		row := &rows[i]
+		if row.Error == "" {
+			if err := prepareImportRow(options, row, currency, categories); err != nil {
+				return report, err
+			}
+		}
+		if row.Error == "" {
+			key := fmt.Sprintf("%s|%s|%s|%s", row.Kind, row.Date.Format("2006-01-02"), row.Amount, row.Description)
+			occurrences[key]++
+			existing, err := repository.CountImportedTransactions(options.UserID, row.Kind, options.CardID, row.Amount, row.Date, row.Description)
+			if err != nil {
+				return report, err
+			}
+			row.Status = models.ImportStatusNew
+			if occurrences[key] <= existing {
+				row.Status = models.ImportStatusDuplicate
+			}
+		}
+
+		if commit && row.Status == models.ImportStatusNew {
+			transaction := importTransaction(options, *row)
+			if err := repository.CreateTransaction(&transaction, options.Audit); err != nil {
+				row.Error = err.Error()
+			} else {
+				row.Status = models.ImportStatusCreated
+			}
+		}
+		if row.Error != "" {
+			row.Status = models.ImportStatusError
+		}
+
+		switch row.Status {
+		case models.ImportStatusNew:
+			report.New++
+		case models.ImportStatusCreated:
+			report.Created++
+		case models.ImportStatusDuplicate:
+			report.Duplicates++
+		case models.ImportStatusError:
+			report.Failed++
+		}
+	}
+
+	report.Total = len(rows)
+	report.Rows = rows
+	if report.Rows == nil {
+		report.Rows = []models.ImportRow{}
+	}
+	return report, nil
+}
+
+// resolveImportCurrency возвращает валюту выписки: явно указанную, иначе валюту карты.
+// Пустая строка означает, что валюту нужно взять из самой выписки (CURDEF в OFX) или по умолчанию.
+func resolveImportCurrency(options ImportOptions) (string, error) {
+	if options.Currency != "" {
+		return normalizeCurrency(options.Currency)
+	}
+	if options.CardID == nil {
+		return "", nil
+	}
+	card, err := GetCardByID(options.UserID, *options.CardID)
+	if err != nil {
+		return "", err
+	}
+	return card.Balance.Currency, nil
+}
+
+type importCategoryKey struct {
+	id           uint
+	categoryType string
+}
+
+// prepareImportRow определяет вид операции и категорию строки. Ошибки, относящиеся к строке,
+// записываются в row.Error; возвращаемая ошибка прерывает весь импорт.
+// categories запоминает результат проверки категорий, чтобы не запрашивать их для каждой строки.
+func prepareImportRow(options ImportOptions, row *models.ImportRow, currency string, categories map[importCategoryKey]error) error {
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if err := inCurrency(&row.Amount, currency, "amount"); err != nil {
+		row.Error = "amount has more fractional digits than " + currency + " allows"
+		return nil
+	}
+
+	categoryType := models.CategoryTypeOutcome
+	switch {
+	case row.Amount.IsZero():
+		row.Error = "amount is zero"
+		return nil
+	case !row.Amount.IsNegative():
+		// Категория по умолчанию относится к списаниям; у дохода остаётся только категория из выписки
+		row.Kind = models.TransactionTypeIncome
+		categoryType = models.CategoryTypeIncome
+	default:
+		row.Kind = models.TransactionTypeExpense
+	}
+
+	if row.Kind == models.TransactionTypeExpense {
+		row.Amount = row.Amount.Neg()
+		if row.CategoryID == nil {
+			row.CategoryID = options.CategoryID
+		}
+		if row.CategoryID == nil {
+			row.Error = "category is required"
+			return nil
+		}
+	}
+	if row.CategoryID == nil {
+		return nil
+	}
+
+	key := importCategoryKey{id: *row.CategoryID, categoryType: categoryType}
+	err, checked := categories[key]
+	if !checked {
+		err = checkCategory(options.UserID, *row.CategoryID, categoryType)
+		if err != nil && !errors.Is(err, errs.ErrValidationFailed) {
+			return err
+		}
+		categories[key] = err
+	}
+	if err != nil {
+		row.Error = fmt.Sprintf("category %d not found", *row.CategoryID)
+	}
+	return nil
+}
+
+// importTransaction превращает строку выписки в операцию; дата операции берётся из выписки
+func importTransaction(options ImportOptions, row models.ImportRow) models.Transaction {
+	return models.Transaction{
+		Type:        row.Kind,
+		Description: row.Description,
+		Amount:      row.Amount,
+		CardID:      options.CardID,
+		CategoryID:  row.CategoryID,
+		Date:        row.Date,
+		Tags:        models.Tags{},
+		UserID:      options.UserID,
+	}
+}
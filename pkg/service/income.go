@@ -3,46 +3,81 @@ package service
 import (
 	"coinkeeper/errs"
 	"coinkeeper/models"
-	"coinkeeper/pkg/repository"
-	"errors"
 )
 
-func GetAllIncome(userID uint, query string) (income []models.Income, err error) {
-	income, err = repository.GetAllIncome(userID, query)
+// Доходы /api/income — представление операций типа income
+
+func GetAllIncome(userID uint, filter models.TransactionFilter, params models.ListParams) (income []models.Income, page models.PageInfo, err error) {
+	filter.Type = models.TransactionTypeIncome
+	transactions, page, err := GetAllTransactions(userID, filter, params)
 	if err != nil {
-		return nil, err
+		return nil, page, err
+	}
+
+	income = make([]models.Income, 0, len(transactions))
+	for _, t := range transactions {
+		income = append(income, models.IncomeFromTransaction(t))
 	}
-	return income, nil
+	return income, page, nil
 }
 
 func GetIncomeByID(userID, incomeID uint) (income models.Income, err error) {
-	income, err = repository.GetIncomeByID(userID, incomeID)
+	transaction, err := getIncomeTransaction(userID, incomeID)
 	if err != nil {
-		if errors.Is(err, errs.ErrRecordNotFound) {
-			return income, errs.ErrOperationNotFound
-		}
 		return models.Income{}, err
 	}
-	return income, nil
+	return models.IncomeFromTransaction(transaction), nil
+}
+
+func CreateIncome(income models.Income, meta models.AuditMeta) error {
+	transaction := income.Transaction()
+	return CreateTransaction(&transaction, meta)
 }
 
-func CreateIncome(income models.Income) error {
-	if err := repository.CreateIncome(income); err != nil {
+// UpdateIncome меняет только переданные поля: нулевые значения оставляют прежние
+func UpdateIncome(income models.Income, meta models.AuditMeta) error {
+	transaction, err := getIncomeTransaction(income.UserID, income.ID)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	if income.Description != "" {
+		transaction.Description = income.Description
+	}
+	if income.CardID != nil {
+		transaction.CardID = income.CardID
+	}
+	if !income.Amount.IsZero() {
+		// Сумма без валюты проводится в валюте карты
+		transaction.Amount = income.Amount
+	}
+	if income.CategoryID != nil {
+		transaction.CategoryID = income.CategoryID
+	}
+	if !income.Date.IsZero() {
+		transaction.Date = income.Date
+	}
+	if income.TimeZone != "" {
+		transaction.TimeZone = income.TimeZone
+	}
+	return UpdateTransaction(transaction, meta)
 }
 
-func UpdateIncome(income models.Income) error {
-	if err := repository.UpdateIncome(income); err != nil {
+func DeleteIncome(incomeID int, userID uint, meta models.AuditMeta) error {
+	if _, err := getIncomeTransaction(userID, uint(incomeID)); err != nil {
 		return err
 	}
-	return nil
+	return DeleteTransaction(uint(incomeID), userID, meta)
 }
 
-func DeleteIncome(incomeID int, userID uint) error {
-	if err := repository.DeleteIncome(incomeID, userID); err != nil {
-		return err
+// getIncomeTransaction возвращает операцию, только если это доход
+func getIncomeTransaction(userID, incomeID uint) (models.Transaction, error) {
+	transaction, err := GetTransactionByID(userID, incomeID)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+	if transaction.Type != models.TransactionTypeIncome {
+		return models.Transaction{}, errs.ErrOperationNotFound
 	}
-	return nil
+	return transaction, nil
 }
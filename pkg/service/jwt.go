@@ -2,7 +2,11 @@ package service
 
 import (
 	"coinkeeper/configs"
+	"coinkeeper/errs"
 	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"coinkeeper/utils"
 	"fmt"
 	"github.com/dgrijalva/jwt-go"
 	"os"
@@ -13,16 +17,30 @@ import (
 type CustomClaims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role"`
+	// TwoFactorSetup — пользователь обязан подключить двухфакторную аутентификацию и ещё не подключил
+	TwoFactorSetup bool `json:"two_factor_setup,omitempty"`
 	jwt.StandardClaims
 }
 
-// GenerateToken генерирует JWT токен с кастомными полями
-func GenerateToken(userID uint, username string, role string) (string, error) {
+// GenerateToken генерирует JWT токен с кастомными полями. Каждый токен получает
+// уникальный jti, по которому его можно отозвать до истечения срока.
+func GenerateToken(user models.User) (string, error) {
+	jti, err := utils.RandomID(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := CustomClaims{
-		UserID:   userID,
-		Username: username,
+		UserID:         user.ID,
+		Username:       user.Username,
+		Role:           user.Role,
+		TwoFactorSetup: isTwoFactorRequired(user) && !user.TwoFactorEnabled,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: int64(time.Duration(configs.AppSettings.AuthParams.JwtTtlMinutes) * time.Minute), // токен истекает через 1 час
+			Id:        jti,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(accessTokenTTL()).Unix(),
 			Issuer:    configs.AppSettings.AppParams.ServerName,
 		},
 	}
@@ -31,6 +49,14 @@ func GenerateToken(userID uint, username string, role string) (string, error) {
 	return token.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
 }
 
+// accessTokenTTL — время жизни access-токена из настроек, по умолчанию 15 минут
+func accessTokenTTL() time.Duration {
+	if minutes := configs.AppSettings.AuthParams.JwtTtlMinutes; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
 // ParseToken парсит JWT токен и возвращает кастомные поля
 func ParseToken(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
@@ -46,7 +72,7 @@ func ParseToken(tokenString string) (*CustomClaims, error) {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
+	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid && claims.Id != "" {
 		return claims, nil
 	}
 
@@ -54,6 +80,23 @@ func ParseToken(tokenString string) (*CustomClaims, error) {
 	return nil, fmt.Errorf("invalid token")
 }
 
+// ValidateAccessToken разбирает access-токен и проверяет, что он не отозван выходом из сессии
+func ValidateAccessToken(tokenString string) (*CustomClaims, error) {
+	claims, err := ParseToken(tokenString)
+	if err != nil {
+		return nil, errs.ErrInvalidToken
+	}
+
+	revoked, err := repository.IsAccessTokenRevoked(claims.UserID, claims.Id, time.Unix(claims.IssuedAt, 0))
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errs.ErrInvalidToken
+	}
+	return claims, nil
+}
+
 /*
 
  */
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"strings"
+)
+
+// normalizeListParams подставляет значения по умолчанию (первая страница, DefaultPageLimit строк,
+// сначала новые) и проверяет, что список можно сортировать по params.Sort
+func normalizeListParams(params models.ListParams, sorts ...string) (models.ListParams, error) {
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.Limit == 0 {
+		params.Limit = models.DefaultPageLimit
+	}
+	if params.Page < 0 || params.Limit < 0 || params.Limit > models.MaxPageLimit {
+		return params, errs.ErrValidationFailed
+	}
+
+	params.Sort = strings.ToLower(strings.TrimSpace(params.Sort))
+	if params.Sort == "" {
+		params.Sort = models.SortByDate
+	}
+	allowed := false
+	for _, sort := range sorts {
+		allowed = allowed || params.Sort == sort
+	}
+	if !allowed {
+		return params, errs.ErrValidationFailed
+	}
+
+	params.Order = strings.ToLower(strings.TrimSpace(params.Order))
+	switch params.Order {
+	case "":
+		params.Order = models.SortOrderDesc
+	case models.SortOrderAsc, models.SortOrderDesc:
+	default:
+		return params, errs.ErrValidationFailed
+	}
+	return params, nil
+}
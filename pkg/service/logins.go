@@ -0,0 +1,181 @@
+package service
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LoginLockedError — вход временно заблокирован из-за неудачных попыток; RetryAfter — когда можно повторить
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return errs.ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return errs.ErrTooManyLoginAttempts
+}
+
+// GetLoginAttempts возвращает страницу журнала неудачных входов, сначала новые
+func GetLoginAttempts(filter models.LoginAttemptFilter, params models.ListParams) (attempts []models.LoginAttempt, page models.PageInfo, err error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, page, errs.ErrValidationFailed
+	}
+	filter.Username = strings.TrimSpace(filter.Username)
+	if params, err = normalizeListParams(params, models.SortByDate); err != nil {
+		return nil, page, err
+	}
+
+	attempts, total, err := repository.GetLoginAttemptsPage(filter, params)
+	if err != nil {
+		return nil, page, err
+	}
+	return attempts, models.NewPageInfo(params, total), nil
+}
+
+// PurgeLoginRecords удаляет устаревшие записи журнала входов и счётчики неудач
+func PurgeLoginRecords(now time.Time) (int64, error) {
+	params := loginProtection()
+	return repository.PurgeLoginRecords(
+		now.AddDate(0, 0, -params.AttemptRetentionDays),
+		now.Add(-time.Duration(params.FailureWindowMinutes)*time.Minute),
+		now,
+	)
+}
+
+// checkLoginLock возвращает *LoginLockedError, если вход по логину или с IP-адреса клиента заблокирован.
+// Попытка входа во время блокировки попадает в журнал, но не продлевает блокировку.
+func checkLoginLock(username string, userID *uint, client models.LoginClient) error {
+	now := time.Now()
+	lockedUntil, err := repository.GetLoginLockedUntil(loginThrottleKeys(username, client), now)
+	if err != nil {
+		return err
+	}
+	if lockedUntil.IsZero() {
+		return nil
+	}
+	logLoginAttempt(username, userID, client, models.LoginFailureLocked)
+	return &LoginLockedError{RetryAfter: lockedUntil.Sub(now)}
+}
+
+// registerLoginFailure засчитывает неудачный вход по логину и по IP-адресу, при превышении
+// порогов блокирует вход и записывает попытку в журнал
+func registerLoginFailure(username string, userID *uint, client models.LoginClient, reason string) error {
+	params := loginProtection()
+	now := time.Now()
+	windowStart := now.Add(-time.Duration(params.FailureWindowMinutes) * time.Minute)
+
+	keys := loginThrottleKeys(username, client)
+	thresholds := [][2]int{
+		{params.UsernameFreeAttempts, params.UsernameLockoutThreshold},
+		{params.IPFreeAttempts, params.IPLockoutThreshold},
+	}
+	for i, key := range keys {
+		failures, err := repository.RegisterLoginFailure(key, now, windowStart)
+		if err != nil {
+			return err
+		}
+		if lock := loginLockDuration(failures, thresholds[i][0], thresholds[i][1], params); lock > 0 {
+			logger.Warn.Printf("[service.registerLoginFailure] %s locked for %s after %d failures\n", key, lock, failures)
+			if err = repository.LockLogin(key, now.Add(lock)); err != nil {
+				return err
+			}
+		}
+	}
+
+	logLoginAttempt(username, userID, client, reason)
+	return nil
+}
+
+// resetLoginFailures сбрасывает счётчик неудач логина после успешного входа. Счётчик IP-адреса
+// не сбрасывается: иначе вход в свою учётную запись позволял бы продолжать подбор чужих паролей.
+func resetLoginFailures(username string, client models.LoginClient) {
+	if err := repository.ResetLoginFailures(loginThrottleKeys(username, client)[0]); err != nil {
+		logger.Error.Println("[service.resetLoginFailures] cannot reset login failures. Error is:", err.Error())
+	}
+}
+
+// logLoginAttempt записывает неудачный вход в журнал; ошибка записи не мешает ответу клиенту
+func logLoginAttempt(username string, userID *uint, client models.LoginClient, reason string) {
+	attempt := models.LoginAttempt{
+		Username:  truncateRunes(strings.TrimSpace(username), 255),
+		UserID:    userID,
+		IPAddress: truncateRunes(client.IPAddress, 64),
+		UserAgent: truncateRunes(client.UserAgent, 512),
+		Reason:    reason,
+	}
+	if err := repository.CreateLoginAttempt(&attempt); err != nil {
+		logger.Error.Println("[service.logLoginAttempt] cannot log login attempt. Error is:", err.Error())
+	}
+}
+
+// loginThrottleKeys — ключи счётчиков неудач: сначала логина, затем IP-адреса клиента
+func loginThrottleKeys(username string, client models.LoginClient) []string {
+	return []string{
+		"user:" + truncateRunes(strings.ToLower(strings.TrimSpace(username)), 255),
+		"ip:" + truncateRunes(client.IPAddress, 64),
+	}
+}
+
+// loginLockDuration — на сколько заблокировать вход после failures неудач подряд: первые free
+// неудач без паузы, затем пауза удваивается от BackoffBaseSeconds до BackoffMaxSeconds,
+// а с lockout неудач — блокировка на LockoutMinutes
+func loginLockDuration(failures, free, lockout int, params models.LoginProtectionParams) time.Duration {
+	if failures >= lockout {
+		return time.Duration(params.LockoutMinutes) * time.Minute
+	}
+	if failures <= free {
+		return 0
+	}
+
+	maxDelay := time.Duration(params.BackoffMaxSeconds) * time.Second
+	delay := time.Duration(params.BackoffBaseSeconds) * time.Second
+	for i := free + 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// loginProtection — пороги защиты входа из настроек с подстановкой значений по умолчанию
+func loginProtection() models.LoginProtectionParams {
+	params := configs.AppSettings.LoginProtectionParams
+	defaults := []struct {
+		value    *int
+		fallback int
+	}{
+		{&params.UsernameFreeAttempts, 3},
+		{&params.UsernameLockoutThreshold, 10},
+		{&params.IPFreeAttempts, 20},
+		{&params.IPLockoutThreshold, 100},
+		{&params.BackoffBaseSeconds, 1},
+		{&params.BackoffMaxSeconds, 300},
+		{&params.LockoutMinutes, 15},
+		{&params.FailureWindowMinutes, 60},
+		{&params.AttemptRetentionDays, 90},
+	}
+	for _, d := range defaults {
+		if *d.value <= 0 {
+			*d.value = d.fallback
+		}
+	}
+	return params
+}
+
+// truncateRunes обрезает строку до limit символов, не разрывая UTF-8
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
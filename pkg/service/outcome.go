@@ -3,46 +3,65 @@ package service
 import (
 	"coinkeeper/errs"
 	"coinkeeper/models"
-	"coinkeeper/pkg/repository"
-	"errors"
 )
 
-func GetAllOutcome(userID uint, query string) (outcome []models.Outcome, err error) {
-	outcome, err = repository.GetAllOutcome(userID, query)
+// Расходы /api/outcome — представление операций типа expense без карты
+
+func GetAllOutcome(userID uint, filter models.TransactionFilter, params models.ListParams) (outcome []models.Outcome, page models.PageInfo, err error) {
+	hasCard := false
+	filter.Type = models.TransactionTypeExpense
+	filter.HasCard = &hasCard
+	transactions, page, err := GetAllTransactions(userID, filter, params)
 	if err != nil {
-		return nil, err
+		return nil, page, err
+	}
+
+	outcome = make([]models.Outcome, 0, len(transactions))
+	for _, t := range transactions {
+		outcome = append(outcome, models.OutcomeFromTransaction(t))
 	}
-	return outcome, nil
+	return outcome, page, nil
 }
 
 func GetOutcomeByID(userID, outcomeID uint) (outcome models.Outcome, err error) {
-	outcome, err = repository.GetOutcomeByID(userID, outcomeID)
+	transaction, err := getOutcomeTransaction(userID, outcomeID)
 	if err != nil {
-		if errors.Is(err, errs.ErrRecordNotFound) {
-			return outcome, errs.ErrOperationNotFound
-		}
-		return outcome, err
+		return models.Outcome{}, err
 	}
-	return outcome, nil
+	return models.OutcomeFromTransaction(transaction), nil
+}
+
+func CreateOutcome(outcome models.Outcome, meta models.AuditMeta) error {
+	transaction := outcome.Transaction()
+	return CreateTransaction(&transaction, meta)
 }
 
-func CreateOutcome(outcome models.Outcome) error {
-	if err := repository.CreateOutcome(outcome); err != nil {
+func UpdateOutcome(outcome models.Outcome, meta models.AuditMeta) error {
+	existing, err := getOutcomeTransaction(outcome.UserID, outcome.ID)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	transaction := outcome.Transaction()
+	transaction.Tags = existing.Tags
+	return UpdateTransaction(transaction, meta)
 }
 
-func UpdateOutcome(outcome models.Outcome) error {
-	if err := repository.UpdateOutcome(outcome); err != nil {
+func DeleteOutcome(outcomeID int, userID uint, meta models.AuditMeta) error {
+	if _, err := getOutcomeTransaction(userID, uint(outcomeID)); err != nil {
 		return err
 	}
-	return nil
+	return DeleteTransaction(uint(outcomeID), userID, meta)
 }
 
-func DeleteOutcome(outcomeID int, userID uint) error {
-	if err := repository.DeleteOutcome(uint(outcomeID), userID); err != nil {
-		return err
+// getOutcomeTransaction возвращает операцию, только если это расход без карты
+func getOutcomeTransaction(userID, outcomeID uint) (models.Transaction, error) {
+	transaction, err := GetTransactionByID(userID, outcomeID)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+	if transaction.Type != models.TransactionTypeExpense || transaction.CardID != nil {
+		return models.Transaction{}, errs.ErrOperationNotFound
 	}
-	return nil
+	return transaction, nil
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/pkg/repository"
+	"errors"
+)
+
+// Проверки владельца. Любое обращение к записи пользователя по идентификатору, пришедшему
+// от клиента, проходит через них: чужая запись — ErrPermissionDenied, несуществующая —
+// ErrOperationNotFound. Запросы репозитория дополнительно ограничены user_id.
+
+// Поиск владельца записи по идентификатору. Тесты подменяют его, чтобы проверять права без базы.
+var (
+	cardOwnerID          = repository.GetCardOwnerID
+	transactionOwnerID   = repository.GetTransactionOwnerID
+	transferOwnerID      = repository.GetTransferOwnerID
+	goalOwnerID          = repository.GetGoalOwnerID
+	budgetOwnerID        = repository.GetBudgetOwnerID
+	recurringRuleOwnerID = repository.GetRecurringRuleOwnerID
+)
+
+// authorizeCard проверяет, что карта cardID принадлежит пользователю userID
+func authorizeCard(userID, cardID uint) error {
+	ownerID, err := cardOwnerID(cardID)
+	return checkOwner(userID, ownerID, err)
+}
+
+// authorizeTransaction проверяет, что операция transactionID принадлежит пользователю userID
+func authorizeTransaction(userID, transactionID uint) error {
+	ownerID, err := transactionOwnerID(transactionID)
+	return checkOwner(userID, ownerID, err)
+}
+
+// authorizeTransfer проверяет, что перевод transferID принадлежит пользователю userID
+func authorizeTransfer(userID, transferID uint) error {
+	ownerID, err := transferOwnerID(transferID)
+	return checkOwner(userID, ownerID, err)
+}
+
+// authorizeGoal проверяет, что цель goalID принадлежит пользователю userID
+func authorizeGoal(userID, goalID uint) error {
+	ownerID, err := goalOwnerID(goalID)
+	return checkOwner(userID, ownerID, err)
+}
+
+// authorizeBudget проверяет, что бюджет budgetID принадлежит пользователю userID
+func authorizeBudget(userID, budgetID uint) error {
+	ownerID, err := budgetOwnerID(budgetID)
+	return checkOwner(userID, ownerID, err)
+}
+
+// authorizeRecurringRule проверяет, что правило ruleID принадлежит пользователю userID
+func authorizeRecurringRule(userID, ruleID uint) error {
+	ownerID, err := recurringRuleOwnerID(ruleID)
+	return checkOwner(userID, ownerID, err)
+}
+
+func checkOwner(userID, ownerID uint, err error) error {
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
+		return err
+	}
+	if userID == 0 || ownerID != userID {
+		return errs.ErrPermissionDenied
+	}
+	return nil
+}
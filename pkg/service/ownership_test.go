@@ -0,0 +1,292 @@
+package service
+
+import (
+	"coinkeeper/db"
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/testdb"
+	"errors"
+	"testing"
+)
+
+// missingID — идентификатор, которого нет ни у одной записи
+const missingID = 999999
+
+// ownedRecords — карта и операции каждого вида, принадлежащие владельцу
+type ownedRecords struct {
+	owner, intruder models.User
+	card            models.Card
+	income          models.Transaction // доход на карту
+	outcome         models.Transaction // расход без карты
+	expense         models.Transaction // трата по карте
+}
+
+func createOwnedRecords(t *testing.T) ownedRecords {
+	t.Helper()
+	testdb.Open(t)
+	r := ownedRecords{
+		owner:    testdb.CreateUser(t, "owner"),
+		intruder: testdb.CreateUser(t, "intruder"),
+	}
+	r.card = testdb.CreateCard(t, r.owner.ID, models.NewMoney(100000, "TJS"))
+
+	category := models.Category{Title: "Groceries", Type: models.CategoryTypeOutcome, UserID: &r.owner.ID}
+	if err := db.GetDBConn().Create(&category).Error; err != nil {
+		t.Fatalf("cannot create category: %v", err)
+	}
+	r.income = createOwnedTransaction(t, models.Transaction{Type: models.TransactionTypeIncome, CardID: &r.card.ID, UserID: r.owner.ID})
+	r.outcome = createOwnedTransaction(t, models.Transaction{Type: models.TransactionTypeExpense, CategoryID: &category.ID, UserID: r.owner.ID})
+	r.expense = createOwnedTransaction(t, models.Transaction{Type: models.TransactionTypeExpense, CardID: &r.card.ID, CategoryID: &category.ID, UserID: r.owner.ID})
+	return r
+}
+
+func createOwnedTransaction(t *testing.T, transaction models.Transaction) models.Transaction {
+	t.Helper()
+	transaction.Amount = models.NewMoney(1000, "TJS")
+	transaction.Description = transaction.Type
+	if err := CreateTransaction(&transaction, models.AuditMeta{ActorID: transaction.UserID}); err != nil {
+		t.Fatalf("cannot create %s: %v", transaction.Type, err)
+	}
+	return transaction
+}
+
+// Чужая карта или операция — 403 (ErrPermissionDenied), несуществующая — 404 (ErrOperationNotFound)
+func TestForeignRecordsAreForbidden(t *testing.T) {
+	r := createOwnedRecords(t)
+	amount := models.NewMoney(500, "TJS")
+
+	tests := []struct {
+		name string
+		id   uint
+		call func(userID, id uint, meta models.AuditMeta) error
+	}{
+		{"get card", r.card.ID, func(userID, id uint, _ models.AuditMeta) error {
+			_, err := GetCardByID(userID, id)
+			return err
+		}},
+		{"update card balance", r.card.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return UpdateCardBalance(userID, id, amount, meta)
+		}},
+		{"delete card", r.card.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return DeleteCard(id, userID, meta)
+		}},
+		{"restore card", r.card.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return RestoreCard(id, userID, meta)
+		}},
+
+		{"get transaction", r.income.ID, func(userID, id uint, _ models.AuditMeta) error {
+			_, err := GetTransactionByID(userID, id)
+			return err
+		}},
+		{"update transaction", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return UpdateTransaction(models.Transaction{ID: id, UserID: userID, Amount: amount, CardID: &r.card.ID}, meta)
+		}},
+		{"delete transaction", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return DeleteTransaction(id, userID, meta)
+		}},
+		{"restore transaction", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return RestoreTransaction(id, userID, meta)
+		}},
+
+		{"get income", r.income.ID, func(userID, id uint, _ models.AuditMeta) error {
+			_, err := GetIncomeByID(userID, id)
+			return err
+		}},
+		{"update income", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return UpdateIncome(models.Income{ID: id, UserID: userID, Amount: amount}, meta)
+		}},
+		{"delete income", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return DeleteIncome(int(id), userID, meta)
+		}},
+		{"restore income", r.income.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return RestoreIncome(id, userID, meta)
+		}},
+
+		{"get outcome", r.outcome.ID, func(userID, id uint, _ models.AuditMeta) error {
+			_, err := GetOutcomeByID(userID, id)
+			return err
+		}},
+		{"update outcome", r.outcome.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return UpdateOutcome(models.Outcome{ID: id, UserID: userID, Amount: amount, CategoryID: *r.outcome.CategoryID}, meta)
+		}},
+		{"delete outcome", r.outcome.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return DeleteOutcome(int(id), userID, meta)
+		}},
+		{"restore outcome", r.outcome.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return RestoreOutcome(id, userID, meta)
+		}},
+
+		{"get expense", r.expense.ID, func(userID, id uint, _ models.AuditMeta) error {
+			_, err := GetExpenseByID(userID, id)
+			return err
+		}},
+		{"update expense", r.expense.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return UpdateExpense(models.Expense{ID: id, UserID: userID, Amount: amount, CardID: r.card.ID, CategoryID: *r.expense.CategoryID}, meta)
+		}},
+		{"delete expense", r.expense.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return DeleteExpense(id, userID, meta)
+		}},
+		{"restore expense", r.expense.ID, func(userID, id uint, meta models.AuditMeta) error {
+			return RestoreExpense(id, userID, meta)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(r.intruder.ID, tt.id, models.AuditMeta{ActorID: r.intruder.ID})
+			if !errors.Is(err, errs.ErrPermissionDenied) {
+				t.Errorf("another user's record: error = %v, want ErrPermissionDenied", err)
+			}
+			err = tt.call(r.owner.ID, missingID, models.AuditMeta{ActorID: r.owner.ID})
+			if !errors.Is(err, errs.ErrOperationNotFound) {
+				t.Errorf("missing record: error = %v, want ErrOperationNotFound", err)
+			}
+		})
+	}
+
+	// Ни один из отклонённых запросов не тронул баланс карты владельца
+	if got := testdb.CardBalance(t, r.card.ID); got.Minor != 100000 {
+		t.Errorf("owner's card balance = %s, want it unchanged", got)
+	}
+}
+
+// Перевод и пополнение цели не могут списывать с чужой карты или зачислять на неё
+func TestForeignCardsInTransfersAndGoals(t *testing.T) {
+	r := createOwnedRecords(t)
+	own := testdb.CreateCard(t, r.intruder.ID, models.NewMoney(100000, "TJS"))
+	amount := models.NewMoney(500, "TJS")
+
+	goal := models.Goal{Title: "Vacation", Target: models.NewMoney(50000, "TJS"), Saved: models.NewMoney(0, "TJS"), UserID: r.intruder.ID}
+	if err := db.GetDBConn().Create(&goal).Error; err != nil {
+		t.Fatalf("cannot create goal: %v", err)
+	}
+	missing := uint(missingID)
+
+	tests := []struct {
+		name string
+		call func(cardID uint) error
+	}{
+		{"transfer from card", func(cardID uint) error {
+			return CreateTransfer(models.Transfer{FromCardID: cardID, ToCardID: own.ID, Amount: amount, UserID: r.intruder.ID}, models.AuditMeta{ActorID: r.intruder.ID})
+		}},
+		{"transfer to card", func(cardID uint) error {
+			return CreateTransfer(models.Transfer{FromCardID: own.ID, ToCardID: cardID, Amount: amount, UserID: r.intruder.ID}, models.AuditMeta{ActorID: r.intruder.ID})
+		}},
+		{"goal contribution from card", func(cardID uint) error {
+			contribution := models.GoalContribution{GoalID: goal.ID, CardID: &cardID, Amount: amount, UserID: r.intruder.ID}
+			return ContributeToGoal(contribution, models.AuditMeta{ActorID: r.intruder.ID})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(r.card.ID); !errors.Is(err, errs.ErrPermissionDenied) {
+				t.Errorf("another user's card: error = %v, want ErrPermissionDenied", err)
+			}
+			if err := tt.call(missing); !errors.Is(err, errs.ErrOperationNotFound) {
+				t.Errorf("missing card: error = %v, want ErrOperationNotFound", err)
+			}
+		})
+	}
+
+	if got := testdb.CardBalance(t, r.card.ID); got.Minor != 100000 {
+		t.Errorf("owner's card balance = %s, want it unchanged", got)
+	}
+	if got := testdb.CardBalance(t, own.ID); got.Minor != 100000 {
+		t.Errorf("intruder's card balance = %s, want it unchanged", got)
+	}
+}
+
+// stubOwners подменяет поиск владельцев: любая запись с идентификатором recordID
+// принадлежит ownerID, остальных записей нет. Обращения к базе до проверки прав не доходят.
+func stubOwners(t *testing.T, recordID, ownerID uint) {
+	t.Helper()
+	lookup := func(id uint) (uint, error) {
+		if id != recordID {
+			return 0, errs.ErrRecordNotFound
+		}
+		return ownerID, nil
+	}
+	saved := []*func(uint) (uint, error){&cardOwnerID, &transactionOwnerID, &transferOwnerID, &goalOwnerID, &budgetOwnerID, &recurringRuleOwnerID}
+	originals := make([]func(uint) (uint, error), len(saved))
+	for i, owner := range saved {
+		originals[i] = *owner
+		*owner = lookup
+	}
+	t.Cleanup(func() {
+		for i, owner := range saved {
+			*owner = originals[i]
+		}
+	})
+}
+
+// Без базы: чужая запись любого вида — ErrPermissionDenied, несуществующая — ErrOperationNotFound
+func TestOwnershipChecks(t *testing.T) {
+	const ownerID, intruderID, recordID = 1, 2, 10
+	stubOwners(t, recordID, ownerID)
+	amount := models.NewMoney(500, "TJS")
+	cardID := uint(recordID)
+
+	tests := []struct {
+		name string
+		call func(userID, id uint) error
+	}{
+		{"get card", func(userID, id uint) error { _, err := GetCardByID(userID, id); return err }},
+		{"update card balance", func(userID, id uint) error { return UpdateCardBalance(userID, id, amount, models.AuditMeta{}) }},
+		{"delete card", func(userID, id uint) error { return DeleteCard(id, userID, models.AuditMeta{}) }},
+		{"restore card", func(userID, id uint) error { return RestoreCard(id, userID, models.AuditMeta{}) }},
+
+		{"get transaction", func(userID, id uint) error { _, err := GetTransactionByID(userID, id); return err }},
+		{"update transaction", func(userID, id uint) error {
+			return UpdateTransaction(models.Transaction{ID: id, UserID: userID, Amount: amount}, models.AuditMeta{})
+		}},
+		{"delete transaction", func(userID, id uint) error { return DeleteTransaction(id, userID, models.AuditMeta{}) }},
+		{"restore transaction", func(userID, id uint) error { return RestoreTransaction(id, userID, models.AuditMeta{}) }},
+		{"get income", func(userID, id uint) error { _, err := GetIncomeByID(userID, id); return err }},
+		{"delete income", func(userID, id uint) error { return DeleteIncome(int(id), userID, models.AuditMeta{}) }},
+		{"get outcome", func(userID, id uint) error { _, err := GetOutcomeByID(userID, id); return err }},
+		{"delete outcome", func(userID, id uint) error { return DeleteOutcome(int(id), userID, models.AuditMeta{}) }},
+		{"get expense", func(userID, id uint) error { _, err := GetExpenseByID(userID, id); return err }},
+		{"delete expense", func(userID, id uint) error { return DeleteExpense(id, userID, models.AuditMeta{}) }},
+
+		{"transfer from card", func(userID, id uint) error {
+			return CreateTransfer(models.Transfer{FromCardID: id, ToCardID: id + 1, Amount: amount, UserID: userID}, models.AuditMeta{})
+		}},
+		{"get transfer", func(userID, id uint) error { _, err := GetTransferByID(userID, id); return err }},
+		{"cancel transfer", func(userID, id uint) error { return CancelTransfer(id, userID, models.AuditMeta{}) }},
+
+		{"get goal", func(userID, id uint) error { _, err := GetGoalByID(userID, id); return err }},
+		{"update goal", func(userID, id uint) error {
+			return UpdateGoal(models.Goal{ID: id, Title: "Vacation", Target: amount, UserID: userID})
+		}},
+		{"delete goal", func(userID, id uint) error { return DeleteGoal(id, userID) }},
+		{"goal contributions", func(userID, id uint) error { _, err := GetGoalContributions(userID, id); return err }},
+		{"contribute to goal", func(userID, id uint) error {
+			contribution := models.GoalContribution{GoalID: id, CardID: &cardID, Amount: amount, UserID: userID}
+			return ContributeToGoal(contribution, models.AuditMeta{})
+		}},
+		{"goal progress", func(userID, id uint) error { _, err := GetGoalProgress(userID, id); return err }},
+
+		{"get budget", func(userID, id uint) error { _, err := GetBudgetByID(userID, id); return err }},
+		{"update budget", func(userID, id uint) error {
+			return UpdateBudget(models.Budget{ID: id, Limit: amount, Year: 2024, Month: 3, UserID: userID})
+		}},
+		{"delete budget", func(userID, id uint) error { return DeleteBudget(id, userID) }},
+		{"budget status", func(userID, id uint) error { _, err := GetBudgetStatus(userID, id); return err }},
+
+		{"get recurring rule", func(userID, id uint) error { _, err := GetRecurringRuleByID(userID, id); return err }},
+		{"update recurring rule", func(userID, id uint) error {
+			return UpdateRecurringRule(models.RecurringRule{ID: id, Type: models.RecurringTypeIncome, Amount: amount, UserID: userID})
+		}},
+		{"delete recurring rule", func(userID, id uint) error { return DeleteRecurringRule(id, userID) }},
+		{"preview recurring rule", func(userID, id uint) error { _, err := PreviewRecurringRule(userID, id, 3); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(intruderID, recordID); !errors.Is(err, errs.ErrPermissionDenied) {
+				t.Errorf("another user's record: error = %v, want ErrPermissionDenied", err)
+			}
+			if err := tt.call(ownerID, missingID); !errors.Is(err, errs.ErrOperationNotFound) {
+				t.Errorf("missing record: error = %v, want ErrOperationNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/mailer"
+	"coinkeeper/pkg/repository"
+	"coinkeeper/utils"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Допустимая длина пароля в символах
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 128
+)
+
+// ChangePassword меняет пароль после проверки текущего. Все сессии пользователя завершаются,
+// а для текущего клиента начинается новая — её токены возвращаются.
+func ChangePassword(userID uint, currentPassword, newPassword string) (tokens models.TokenPair, err error) {
+	if err = validatePassword(newPassword); err != nil {
+		return tokens, err
+	}
+
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return tokens, err
+	}
+	ok, _, err := utils.VerifyPassword(currentPassword, user.Password)
+	if err != nil {
+		logger.Error.Printf("[service.ChangePassword] cannot verify password of user %d. Error is: %s\n", user.ID, err.Error())
+		return tokens, errs.ErrIncorrectPassword
+	}
+	if !ok {
+		return tokens, errs.ErrIncorrectPassword
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return tokens, err
+	}
+	// Как и в LogoutAll, токен новой сессии, выданный в ту же секунду, должен остаться действительным
+	if err = repository.ChangeUserPassword(user.ID, hash, time.Now().Truncate(time.Second)); err != nil {
+		return tokens, err
+	}
+	return startSession(user)
+}
+
+// ForgotPassword отправляет на email ссылку для сброса пароля. Ответ не зависит от того,
+// есть ли пользователь с таким адресом, поэтому ошибки поиска и отправки только пишутся в лог.
+func ForgotPassword(email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := repository.GetUserByEmail(email)
+	if err != nil {
+		if !errors.Is(err, errs.ErrRecordNotFound) {
+			logger.Error.Println("[service.ForgotPassword] cannot find user by email. Error is:", err.Error())
+		}
+		return nil
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	record := models.PasswordResetToken{
+		TokenHash: utils.GenerateHash(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(passwordResetTTL()),
+	}
+	if err = repository.CreatePasswordResetToken(&record); err != nil {
+		logger.Error.Printf("[service.ForgotPassword] cannot create reset token for user %d. Error is: %s\n", user.ID, err.Error())
+		return nil
+	}
+
+	// Письмо уходит в фоне: время ответа не должно выдавать, что адрес найден
+	msg := passwordResetMessage(user, token)
+	go func() {
+		if err := mailer.Send(msg); err != nil {
+			logger.Error.Printf("[service.ForgotPassword] cannot send reset mail to user %d. Error is: %s\n", user.ID, err.Error())
+		}
+	}()
+	return nil
+}
+
+// ResetPassword задаёт новый пароль по токену из письма. Токен одноразовый; после сброса
+// остальные токены сброса и все сессии пользователя перестают действовать.
+func ResetPassword(token, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	record, err := repository.GetPasswordResetTokenByHash(utils.GenerateHash(token))
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrInvalidResetToken
+		}
+		return err
+	}
+	now := time.Now()
+	if record.UsedAt != nil || !record.ExpiresAt.After(now) {
+		return errs.ErrInvalidResetToken
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return repository.ResetUserPassword(record.ID, record.UserID, hash, now.Truncate(time.Second))
+}
+
+func passwordResetMessage(user models.User, token string) mailer.Message {
+	ttl := passwordResetTTL()
+	link := token
+	if resetURL := configs.AppSettings.MailParams.ResetPasswordURL; resetURL != "" {
+		link = resetURL + "?" + url.Values{"token": {token}}.Encode()
+	}
+
+	name := user.FullName
+	if name == "" {
+		name = user.Username
+	}
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "CoinKeeper: сброс пароля",
+		Body: fmt.Sprintf("Здравствуйте, %s!\n\n"+
+			"Для сброса пароля перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d мин. и может быть использована один раз.\n"+
+			"Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.\n",
+			name, link, int(ttl/time.Minute)),
+	}
+}
+
+// passwordResetTTL — время жизни токена сброса пароля из настроек, по умолчанию 30 минут
+func passwordResetTTL() time.Duration {
+	if minutes := configs.AppSettings.AuthParams.PasswordResetTtlMinutes; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return 30 * time.Minute
+}
+
+func validatePassword(password string) error {
+	if length := utf8.RuneCountInString(password); length < minPasswordLength || length > maxPasswordLength {
+		return errs.NewFieldError("new_password", errs.FieldInvalid,
+			fmt.Sprintf("must have %d to %d characters", minPasswordLength, maxPasswordLength))
+	}
+	return nil
+}
+
+// normalizeEmail приводит адрес к нижнему регистру и проверяет, что это адрес без имени и скобок
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", errs.NewFieldError("email", errs.FieldInvalid, "must be a valid email address")
+	}
+	return email, nil
+}
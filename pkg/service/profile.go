@@ -0,0 +1,170 @@
+package service
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"coinkeeper/utils"
+	"golang.org/x/text/language"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GetProfile возвращает профиль текущего пользователя без хеша пароля
+func GetProfile(userID uint) (models.User, error) {
+	return GetUserByID(userID)
+}
+
+// UpdateProfile меняет имя, базовую валюту, язык и часовой пояс пользователя; пустые поля не меняются
+func UpdateProfile(userID uint, input models.ProfileInput) (user models.User, err error) {
+	user, err = repository.GetUserByID(userID)
+	if err != nil {
+		return user, err
+	}
+
+	if input.FullName = strings.TrimSpace(input.FullName); input.FullName != "" {
+		user.FullName = input.FullName
+	}
+	if input.BaseCurrency != "" {
+		if user.BaseCurrency, err = normalizeCurrency(input.BaseCurrency); err != nil {
+			return user, errs.NewFieldError("base_currency", errs.FieldInvalid, "must be a three-letter ISO 4217 code")
+		}
+	}
+	if input.Locale != "" {
+		if user.Locale, err = normalizeLocale(input.Locale); err != nil {
+			return user, err
+		}
+	}
+	if input.TimeZone != "" {
+		if err = validateTimeZone(input.TimeZone); err != nil {
+			return user, err
+		}
+		user.TimeZone = input.TimeZone
+	}
+
+	if err = repository.UpdateUserProfile(user); err != nil {
+		return user, err
+	}
+	user.Password = ""
+	return user, nil
+}
+
+// GetUserLocation возвращает часовой пояс из профиля пользователя, по умолчанию — зону сервера
+func GetUserLocation(userID uint) (*time.Location, error) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TimeZone == "" {
+		return serverLocation(), nil
+	}
+	location, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		logger.Error.Printf("[service.GetUserLocation] invalid time zone of user %d. Error is: %s\n", userID, err.Error())
+		return serverLocation(), nil
+	}
+	return location, nil
+}
+
+// serverLocation — зона сервера под её IANA-именем: time.Local называется просто "Local",
+// а в SQL зона передаётся по имени. Имя берётся из TZ, /etc/timezone или ссылки /etc/localtime;
+// если его не узнать, зоной сервера считается UTC.
+var serverLocation = sync.OnceValue(func() *time.Location {
+	name := strings.TrimPrefix(os.Getenv("TZ"), ":")
+	if name == "" {
+		if data, err := os.ReadFile("/etc/timezone"); err == nil {
+			name = strings.TrimSpace(string(data))
+		}
+	}
+	if name == "" {
+		if target, err := os.Readlink("/etc/localtime"); err == nil {
+			_, name, _ = strings.Cut(target, "zoneinfo/")
+		}
+	}
+	if name != "" {
+		if location, err := time.LoadLocation(name); err == nil {
+			return location
+		}
+	}
+	return time.UTC
+})
+
+// timeZoneName возвращает IANA-имя зоны location для передачи в SQL
+func timeZoneName(location *time.Location) string {
+	if location == time.Local {
+		return serverLocation().String()
+	}
+	return location.String()
+}
+
+// DeleteAccount удаляет учётную запись после проверки пароля и, если включена, двухфакторной
+// аутентификации. Доступ закрывается сразу, а данные стираются после срока хранения.
+func DeleteAccount(userID uint, password, code string) error {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	ok, _, err := utils.VerifyPassword(password, user.Password)
+	if err != nil || !ok {
+		return errs.ErrIncorrectPassword
+	}
+	if user.TwoFactorEnabled {
+		if err = verifySecondFactor(user, code, true); err != nil {
+			return err
+		}
+	}
+	return repository.DeleteUser(user.ID, time.Now().Truncate(time.Second))
+}
+
+// PurgeDeletedAccounts окончательно стирает учётные записи, удалённые раньше срока хранения,
+// вместе со всеми данными. Ошибка одной учётной записи не мешает остальным.
+func PurgeDeletedAccounts(now time.Time) (purged int64, err error) {
+	users, err := repository.GetUsersToPurge(now.AddDate(0, 0, -accountDeletionGraceDays()))
+	if err != nil {
+		return 0, err
+	}
+	for _, user := range users {
+		if err = repository.PurgeUser(user); err != nil {
+			logger.Error.Printf("[service.PurgeDeletedAccounts] cannot purge user %d. Error is: %s\n", user.ID, err.Error())
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// accountDeletionGraceDays — срок хранения удалённых учётных записей из настроек, по умолчанию 30 дней
+func accountDeletionGraceDays() int {
+	if days := configs.AppSettings.AccountParams.DeletionGraceDays; days > 0 {
+		return days
+	}
+	return 30
+}
+
+// normalizeLocale приводит тег языка BCP 47 к каноническому виду (ru-ru → ru-RU); пустой тег допустим
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", errs.NewFieldError("locale", errs.FieldInvalid, "must be a BCP 47 language tag")
+	}
+	return tag.String(), nil
+}
+
+// validateTimeZone проверяет IANA-зону; пустая зона означает зону сервера
+func validateTimeZone(timeZone string) error {
+	if timeZone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return errs.NewFieldError("time_zone", errs.FieldInvalid, "must be an IANA time zone")
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+func GetAllExchangeRates(userID uint) (rates []models.ExchangeRate, err error) {
+	rates, err = repository.GetAllExchangeRates(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func GetExchangeRateByID(userID, rateID uint) (rate models.ExchangeRate, err error) {
+	rate, err = repository.GetExchangeRateByID(userID, rateID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return rate, errs.ErrOperationNotFound
+		}
+		return rate, err
+	}
+	return rate, nil
+}
+
+func CreateExchangeRate(rate models.ExchangeRate) error {
+	if err := validateExchangeRate(&rate); err != nil {
+		return err
+	}
+
+	_, err := repository.GetExchangeRate(rate.UserID, rate.BaseCurrency, rate.QuoteCurrency)
+	if err == nil {
+		return errs.ErrExchangeRateAlreadyExists
+	}
+	if !errors.Is(err, errs.ErrRecordNotFound) {
+		return err
+	}
+
+	return repository.CreateExchangeRate(rate)
+}
+
+func UpdateExchangeRate(rate models.ExchangeRate) error {
+	if err := validateExchangeRate(&rate); err != nil {
+		return err
+	}
+	if _, err := GetExchangeRateByID(rate.UserID, rate.ID); err != nil {
+		return err
+	}
+
+	existing, err := repository.GetExchangeRate(rate.UserID, rate.BaseCurrency, rate.QuoteCurrency)
+	if err == nil && existing.ID != rate.ID {
+		return errs.ErrExchangeRateAlreadyExists
+	}
+	if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+		return err
+	}
+
+	return repository.UpdateExchangeRate(rate)
+}
+
+func DeleteExchangeRate(rateID, userID uint) error {
+	if _, err := GetExchangeRateByID(userID, rateID); err != nil {
+		return err
+	}
+	return repository.DeleteExchangeRate(rateID, userID)
+}
+
+func validateExchangeRate(rate *models.ExchangeRate) (err error) {
+	if rate.BaseCurrency, err = normalizeCurrency(rate.BaseCurrency); err != nil {
+		return errs.NewFieldError("base_currency", errs.FieldInvalid, "must be a three-letter ISO 4217 code")
+	}
+	if rate.QuoteCurrency, err = normalizeCurrency(rate.QuoteCurrency); err != nil {
+		return errs.NewFieldError("quote_currency", errs.FieldInvalid, "must be a three-letter ISO 4217 code")
+	}
+	if rate.BaseCurrency == rate.QuoteCurrency {
+		return errs.NewFieldError("quote_currency", errs.FieldInvalid, "must differ from base_currency")
+	}
+
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate.Rate))
+	if !ok {
+		return errs.NewFieldError("rate", errs.FieldInvalid, "must be a decimal number")
+	}
+	if r.Sign() <= 0 {
+		return errs.NewFieldError("rate", errs.FieldTooSmall, "must be greater than 0")
+	}
+	rate.Rate = r.FloatString(12)
+	return nil
+}
+
+// normalizeCurrency приводит код валюты к виду ISO 4217: три заглавные латинские буквы
+func normalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", errs.ErrValidationFailed
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", errs.ErrValidationFailed
+		}
+	}
+	return code, nil
+}
+
+// inCurrency переводит сумму, пришедшую без валюты, в валюту currency: её десятичная запись
+// разбирается по числу знаков этой валюты. Сумма с валютой не меняется. Лишние знаки после
+// запятой — ошибка поля field.
+func inCurrency(amount *models.Money, currency, field string) error {
+	converted, err := amount.InCurrency(currency)
+	if err != nil {
+		return errs.NewFieldError(field, errs.FieldInvalid, "has more fractional digits than "+currency+" allows")
+	}
+	*amount = converted
+	return nil
+}
+
+// findRate возвращает курс from -> to по сохранённой прямой или обратной паре
+func findRate(userID uint, from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	rate, err := repository.GetExchangeRate(userID, from, to)
+	if err == nil {
+		r, _ := new(big.Rat).SetString(rate.Rate)
+		return r, nil
+	}
+	if !errors.Is(err, errs.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	rate, err = repository.GetExchangeRate(userID, to, from)
+	if err == nil {
+		r, _ := new(big.Rat).SetString(rate.Rate)
+		return r.Inv(r), nil
+	}
+	if errors.Is(err, errs.ErrRecordNotFound) {
+		return nil, errs.ErrExchangeRateNotFound
+	}
+	return nil, err
+}
+
+// ConvertMoney пересчитывает сумму в валюту to по курсам пользователя.
+// Результат округляется до минорной единицы валюты to (половина — от нуля).
+func ConvertMoney(userID uint, amount models.Money, to string) (models.Money, error) {
+	to, err := normalizeCurrency(to)
+	if err != nil {
+		return models.Money{}, err
+	}
+	if amount.Currency == to {
+		return amount, nil
+	}
+
+	rate, err := findRate(userID, amount.Currency, to)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	value := new(big.Rat).SetInt64(amount.Minor)
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetFrac(
+		pow10(models.CurrencyExponent(to)),
+		pow10(models.CurrencyExponent(amount.Currency)),
+	))
+	return models.NewMoney(roundRat(value), to), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundRat округляет дробь до целого, половину — от нуля
+func roundRat(r *big.Rat) int64 {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		doubled := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		if doubled.Cmp(r.Denom()) >= 0 {
+			quo.Add(quo, big.NewInt(int64(r.Sign())))
+		}
+	}
+	return quo.Int64()
+}
+
+// resolveReportCurrency возвращает запрошенную валюту либо базовую валюту пользователя
+func resolveReportCurrency(userID uint, currency string) (string, error) {
+	if currency != "" {
+		return normalizeCurrency(currency)
+	}
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return "", err
+	}
+	if user.BaseCurrency == "" {
+		return models.DefaultCurrency, nil
+	}
+	return user.BaseCurrency, nil
+}
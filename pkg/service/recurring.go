@@ -0,0 +1,349 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"coinkeeper/utils"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// recurringBatchSize — сколько правил воркер берёт за один проход
+	recurringBatchSize = 100
+	// recurringCatchUpLimit — сколько пропущенных операций одного правила догоняется за один проход
+	recurringCatchUpLimit = 366
+	// recurringPreviewMax — максимальное число операций в предпросмотре
+	recurringPreviewMax = 100
+	// recurringMaxFailures — после стольких неудач подряд правило выключается
+	recurringMaxFailures = 10
+	// recurringRetryBase и recurringRetryMax — первая и наибольшая пауза перед повтором после неудачи
+	recurringRetryBase = time.Minute
+	recurringRetryMax  = 24 * time.Hour
+)
+
+func GetAllRecurringRules(userID uint) (rules []models.RecurringRule, err error) {
+	rules, err = repository.GetAllRecurringRules(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func GetRecurringRuleByID(userID, ruleID uint) (rule models.RecurringRule, err error) {
+	if err = authorizeRecurringRule(userID, ruleID); err != nil {
+		return models.RecurringRule{}, err
+	}
+	rule, err = repository.GetRecurringRuleByID(userID, ruleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return rule, errs.ErrOperationNotFound
+		}
+		return rule, err
+	}
+	return rule, nil
+}
+
+func CreateRecurringRule(rule models.RecurringRule) error {
+	if err := validateRecurringRule(&rule); err != nil {
+		return err
+	}
+	rule.ID = 0
+	rule.LastRunAt = nil
+	rule.Failures, rule.RetryAt, rule.LastError = 0, nil, ""
+	return repository.CreateRecurringRule(rule)
+}
+
+// UpdateRecurringRule сохраняет правило и пересчитывает дату следующей операции
+// от последней уже созданной. Изменённое правило воркер снова пробует сразу.
+func UpdateRecurringRule(rule models.RecurringRule) error {
+	existing, err := GetRecurringRuleByID(rule.UserID, rule.ID)
+	if err != nil {
+		return err
+	}
+	if err = validateRecurringRule(&rule); err != nil {
+		return err
+	}
+
+	if existing.LastRunAt != nil {
+		rule.NextRunAt, err = nextOccurrence(rule, *existing.LastRunAt)
+		if err != nil {
+			return err
+		}
+	}
+	rule.Failures, rule.RetryAt, rule.LastError = 0, nil, ""
+	return repository.UpdateRecurringRule(rule)
+}
+
+func DeleteRecurringRule(ruleID, userID uint) error {
+	if _, err := GetRecurringRuleByID(userID, ruleID); err != nil {
+		return err
+	}
+	return repository.DeleteRecurringRule(ruleID, userID)
+}
+
+func validateRecurringRule(rule *models.RecurringRule) (err error) {
+	rule.Frequency = strings.ToLower(strings.TrimSpace(rule.Frequency))
+	rule.Type = strings.ToLower(strings.TrimSpace(rule.Type))
+
+	switch rule.Type {
+	case models.RecurringTypeIncome:
+	case models.RecurringTypeOutcome:
+		if rule.CategoryID == nil {
+			return errs.NewFieldError("category_id", errs.FieldRequired, "is required for outcome rules")
+		}
+		// Расход outcome не списывается с карты: для расхода по карте есть правила expense
+		if rule.CardID != nil {
+			return errs.NewFieldError("card_id", errs.FieldInvalid, "must be empty for outcome rules, use an expense rule")
+		}
+	case models.RecurringTypeExpense:
+		if rule.CategoryID == nil {
+			return errs.NewFieldError("category_id", errs.FieldRequired, "is required for expense rules")
+		}
+		if rule.CardID == nil {
+			return errs.NewFieldError("card_id", errs.FieldRequired, "is required for expense rules")
+		}
+	case "":
+		return errs.NewFieldError("type", errs.FieldRequired, "is required")
+	default:
+		return errs.NewFieldError("type", errs.FieldInvalid, "must be one of: income, outcome, expense")
+	}
+	if rule.CategoryID != nil {
+		categoryType := models.CategoryTypeOutcome
+		if rule.Type == models.RecurringTypeIncome {
+			categoryType = models.CategoryTypeIncome
+		}
+		if err = checkCategory(rule.UserID, *rule.CategoryID, categoryType); err != nil {
+			if errors.Is(err, errs.ErrValidationFailed) {
+				return errs.NewFieldError("category_id", errs.FieldNotFound, categoryType+" category not found")
+			}
+			return err
+		}
+	}
+
+	if rule.Amount.Minor <= 0 {
+		return errs.NewFieldError("amount", errs.FieldTooSmall, "must be greater than 0")
+	}
+	if rule.CardID != nil {
+		card, err := GetCardByID(rule.UserID, *rule.CardID)
+		if err != nil {
+			return err
+		}
+		if err = inCurrency(&rule.Amount, card.Balance.Currency, "amount"); err != nil {
+			return err
+		}
+	}
+	if err := inCurrency(&rule.Amount, models.DefaultCurrency, "amount"); err != nil {
+		return err
+	}
+
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.Interval < 0 {
+		return errs.NewFieldError("interval", errs.FieldTooSmall, "must be at least 1")
+	}
+	switch rule.Frequency {
+	case models.FrequencyDaily, models.FrequencyWeekly, models.FrequencyMonthly, models.FrequencyYearly:
+		rule.CronExpr = ""
+	case models.FrequencyCron:
+		if _, err = utils.ParseCron(rule.CronExpr); err != nil {
+			return errs.NewFieldError("cron_expr", errs.FieldInvalid, "must be a five-field cron expression")
+		}
+	case "":
+		return errs.NewFieldError("frequency", errs.FieldRequired, "is required")
+	default:
+		return errs.NewFieldError("frequency", errs.FieldInvalid, "must be one of: daily, weekly, monthly, yearly, cron")
+	}
+
+	if rule.StartDate.IsZero() {
+		rule.StartDate = time.Now()
+	}
+	if rule.EndDate != nil && rule.EndDate.Before(rule.StartDate) {
+		return errs.NewFieldError("end_date", errs.FieldInvalid, "must not be before start_date")
+	}
+
+	rule.NextRunAt, err = firstOccurrence(*rule)
+	return err
+}
+
+// firstOccurrence — первая операция правила не раньше StartDate
+func firstOccurrence(rule models.RecurringRule) (*time.Time, error) {
+	if rule.Frequency == models.FrequencyCron {
+		return nextOccurrence(rule, rule.StartDate.Add(-time.Minute))
+	}
+	return withinEndDate(rule, rule.StartDate), nil
+}
+
+// nextOccurrence — первая операция правила строго после after; nil, если правило закончилось
+func nextOccurrence(rule models.RecurringRule, after time.Time) (*time.Time, error) {
+	start := rule.StartDate
+	if after.Before(start) && rule.Frequency != models.FrequencyCron {
+		return firstOccurrence(rule)
+	}
+
+	var next time.Time
+	switch rule.Frequency {
+	case models.FrequencyDaily, models.FrequencyWeekly:
+		days := rule.Interval
+		if rule.Frequency == models.FrequencyWeekly {
+			days *= 7
+		}
+		k := int(after.Sub(start).Hours()/24) / days
+		for next = start.AddDate(0, 0, k*days); !next.After(after); next = start.AddDate(0, 0, k*days) {
+			k++
+		}
+	case models.FrequencyMonthly, models.FrequencyYearly:
+		months := rule.Interval
+		if rule.Frequency == models.FrequencyYearly {
+			months *= 12
+		}
+		k := ((after.Year()-start.Year())*12 + int(after.Month()) - int(start.Month())) / months
+		for next = addMonthsClamped(start, k*months); !next.After(after); next = addMonthsClamped(start, k*months) {
+			k++
+		}
+	case models.FrequencyCron:
+		schedule, err := utils.ParseCron(rule.CronExpr)
+		if err != nil {
+			return nil, errs.ErrValidationFailed
+		}
+		if after.Before(start) {
+			after = start.Add(-time.Minute)
+		}
+		next = schedule.Next(after)
+		if next.IsZero() {
+			return nil, nil
+		}
+	default:
+		return nil, errs.ErrValidationFailed
+	}
+	return withinEndDate(rule, next), nil
+}
+
+func withinEndDate(rule models.RecurringRule, t time.Time) *time.Time {
+	if rule.EndDate != nil && t.After(*rule.EndDate) {
+		return nil
+	}
+	return &t
+}
+
+// addMonthsClamped прибавляет месяцы к anchor, прижимая день к концу месяца:
+// правило на 31-е число сработает 28 (29) февраля и 30 апреля
+func addMonthsClamped(anchor time.Time, months int) time.Time {
+	year, month, day := anchor.Date()
+	first := time.Date(year, month+time.Month(months), 1, anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), anchor.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// PreviewRecurringRule показывает ближайшие операции правила без их создания.
+// Уже наступившие, но ещё не созданные операции помечены Due — их создаст следующий проход воркера.
+func PreviewRecurringRule(userID, ruleID uint, count int) ([]models.RecurringPreviewItem, error) {
+	rule, err := GetRecurringRuleByID(userID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 || count > recurringPreviewMax {
+		return nil, errs.ErrValidationFailed
+	}
+
+	now := time.Now()
+	items := []models.RecurringPreviewItem{}
+	for next := rule.NextRunAt; next != nil && len(items) < count; {
+		items = append(items, models.RecurringPreviewItem{
+			ScheduledAt: *next,
+			Amount:      rule.Amount,
+			Due:         rule.IsActive && !next.After(now),
+		})
+		if next, err = nextOccurrence(rule, *next); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// RunDueRecurringRules создаёт операции по всем наступившим датам правил, включая пропущенные
+// за время простоя. Возвращает число созданных операций.
+func RunDueRecurringRules(now time.Time) (created int, err error) {
+	rules, err := repository.GetDueRecurringRules(now, recurringBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rule := range rules {
+		for i := 0; i < recurringCatchUpLimit && rule.NextRunAt != nil && !rule.NextRunAt.After(now); i++ {
+			scheduledAt := *rule.NextRunAt
+			next, err := nextOccurrence(rule, scheduledAt)
+			if err != nil {
+				logger.Error.Printf("[service.RunDueRecurringRules] cannot compute next run of rule %d. Error is: %s\n", rule.ID, err.Error())
+				postponeRecurringRule(rule, now, err)
+				break
+			}
+
+			ok, err := repository.MaterializeRecurringOccurrence(rule, scheduledAt, next, recurringTransaction(rule, scheduledAt))
+			if err != nil {
+				logger.Error.Printf("[service.RunDueRecurringRules] cannot materialize rule %d. Error is: %s\n", rule.ID, err.Error())
+				postponeRecurringRule(rule, now, err)
+				break
+			}
+			if ok {
+				created++
+			}
+			rule.NextRunAt = next
+			rule.Failures = 0
+		}
+	}
+	return created, nil
+}
+
+// postponeRecurringRule откладывает правило, по которому не удалось создать операцию, чтобы оно
+// не занимало очередь воркера: пауза удваивается с каждой неудачей подряд от recurringRetryBase
+// до recurringRetryMax, а после recurringMaxFailures неудач правило выключается
+func postponeRecurringRule(rule models.RecurringRule, now time.Time, cause error) {
+	rule.Failures++
+	delay := recurringRetryBase
+	for i := 1; i < rule.Failures && delay < recurringRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > recurringRetryMax {
+		delay = recurringRetryMax
+	}
+	retryAt := now.Add(delay)
+	rule.RetryAt = &retryAt
+	rule.LastError = cause.Error()
+	if rule.Failures >= recurringMaxFailures {
+		rule.IsActive = false
+		logger.Warn.Printf("[service.postponeRecurringRule] rule %d deactivated after %d failures\n", rule.ID, rule.Failures)
+	}
+
+	if err := repository.RecordRecurringFailure(rule); err != nil {
+		logger.Error.Printf("[service.postponeRecurringRule] cannot postpone rule %d. Error is: %s\n", rule.ID, err.Error())
+	}
+}
+
+// recurringTransaction строит операцию, которую правило создаёт за дату scheduledAt.
+// Правила outcome и expense создают расход без карты и с картой соответственно.
+func recurringTransaction(rule models.RecurringRule, scheduledAt time.Time) *models.Transaction {
+	transaction := &models.Transaction{
+		Type:        models.TransactionTypeExpense,
+		Description: rule.Description,
+		Amount:      rule.Amount,
+		CategoryID:  rule.CategoryID,
+		Date:        scheduledAt,
+		Tags:        models.Tags{},
+		UserID:      rule.UserID,
+	}
+	switch rule.Type {
+	case models.RecurringTypeIncome:
+		transaction.Type = models.TransactionTypeIncome
+		transaction.CardID = rule.CardID
+	case models.RecurringTypeExpense:
+		transaction.CardID = rule.CardID
+	}
+	return transaction
+}
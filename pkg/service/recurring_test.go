@@ -0,0 +1,26 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"errors"
+	"testing"
+)
+
+// Правило outcome создаёт расход без карты, поэтому карта в нём — ошибка поля, а не молча игнорируемое значение
+func TestOutcomeRuleRejectsCard(t *testing.T) {
+	categoryID, cardID := uint(1), uint(2)
+	rule := models.RecurringRule{
+		Type:       models.RecurringTypeOutcome,
+		Amount:     models.NewMoney(1000, "TJS"),
+		CategoryID: &categoryID,
+		CardID:     &cardID,
+		Frequency:  models.FrequencyMonthly,
+		UserID:     1,
+	}
+	err := validateRecurringRule(&rule)
+	var validation *errs.ValidationError
+	if !errors.As(err, &validation) || len(validation.Fields) != 1 || validation.Fields[0].Field != "card_id" {
+		t.Fatalf("validateRecurringRule error = %v, want a card_id field error", err)
+	}
+}
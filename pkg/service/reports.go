@@ -0,0 +1,133 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"time"
+)
+
+// reportGroupings — допустимые значения group_by и соответствующие единицы date_trunc
+var reportGroupings = map[string]string{
+	"day":   "day",
+	"week":  "week",
+	"month": "month",
+	"year":  "year",
+}
+
+// GetReportSummary считает доходы, расходы и их разницу за [from, to) по периодам,
+// категориям и картам. Операции относятся к периоду по дате операции, границы периодов
+// считаются в зоне location. Суммы пересчитываются в currency (по умолчанию — базовая валюта пользователя).
+func GetReportSummary(userID uint, from, to time.Time, groupBy, currency string, location *time.Location) (summary models.ReportSummary, err error) {
+	unit, ok := reportGroupings[groupBy]
+	if !ok || !from.Before(to) {
+		return summary, errs.ErrValidationFailed
+	}
+
+	summary.From, summary.To, summary.GroupBy = from, to, groupBy
+	if summary.Currency, err = resolveReportCurrency(userID, currency); err != nil {
+		return summary, err
+	}
+	summary.Totals = newReportTotals(summary.Currency)
+	summary.Periods = []models.ReportPeriod{}
+	summary.ByCategory = []models.ReportCategory{}
+	summary.ByCard = []models.ReportCard{}
+
+	rows, err := repository.GetReportByPeriod(userID, from, to, unit, timeZoneName(location))
+	if err != nil {
+		return summary, err
+	}
+	periodIndex := map[time.Time]int{}
+	for _, row := range rows {
+		amount, err := ConvertMoney(userID, models.NewMoney(row.Minor, row.Currency), summary.Currency)
+		if err != nil {
+			return summary, err
+		}
+
+		i, ok := periodIndex[row.Period]
+		if !ok {
+			i = len(summary.Periods)
+			periodIndex[row.Period] = i
+			summary.Periods = append(summary.Periods, models.ReportPeriod{
+				PeriodStart:  row.Period.In(location),
+				ReportTotals: newReportTotals(summary.Currency),
+			})
+		}
+		addToReportTotals(&summary.Periods[i].ReportTotals, row.Kind, amount)
+		addToReportTotals(&summary.Totals, row.Kind, amount)
+	}
+
+	rows, err = repository.GetReportByCategory(userID, from, to)
+	if err != nil {
+		return summary, err
+	}
+	categoryIndex := map[uint]int{}
+	for _, row := range rows {
+		if row.CategoryID == nil {
+			continue
+		}
+		amount, err := ConvertMoney(userID, models.NewMoney(row.Minor, row.Currency), summary.Currency)
+		if err != nil {
+			return summary, err
+		}
+
+		i, ok := categoryIndex[*row.CategoryID]
+		if !ok {
+			i = len(summary.ByCategory)
+			categoryIndex[*row.CategoryID] = i
+			summary.ByCategory = append(summary.ByCategory, models.ReportCategory{
+				CategoryID: *row.CategoryID,
+				Title:      row.Title,
+				Outcome:    models.NewMoney(0, summary.Currency),
+			})
+		}
+		summary.ByCategory[i].Outcome.Minor += amount.Minor
+	}
+
+	rows, err = repository.GetReportByCard(userID, from, to)
+	if err != nil {
+		return summary, err
+	}
+	cardIndex := map[uint]int{}
+	for _, row := range rows {
+		if row.CardID == nil {
+			continue
+		}
+		amount, err := ConvertMoney(userID, models.NewMoney(row.Minor, row.Currency), summary.Currency)
+		if err != nil {
+			return summary, err
+		}
+
+		i, ok := cardIndex[*row.CardID]
+		if !ok {
+			i = len(summary.ByCard)
+			cardIndex[*row.CardID] = i
+			summary.ByCard = append(summary.ByCard, models.ReportCard{
+				CardID:       *row.CardID,
+				CardNumber:   row.CardNumber,
+				ReportTotals: newReportTotals(summary.Currency),
+			})
+		}
+		addToReportTotals(&summary.ByCard[i].ReportTotals, row.Kind, amount)
+	}
+
+	return summary, nil
+}
+
+func newReportTotals(currency string) models.ReportTotals {
+	return models.ReportTotals{
+		Income:  models.NewMoney(0, currency),
+		Outcome: models.NewMoney(0, currency),
+		Net:     models.NewMoney(0, currency),
+	}
+}
+
+func addToReportTotals(totals *models.ReportTotals, kind string, amount models.Money) {
+	if kind == models.ReportKindIncome {
+		totals.Income.Minor += amount.Minor
+		totals.Net.Minor += amount.Minor
+		return
+	}
+	totals.Outcome.Minor += amount.Minor
+	totals.Net.Minor -= amount.Minor
+}
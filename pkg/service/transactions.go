@@ -0,0 +1,210 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"strings"
+	"time"
+)
+
+// maxTransactionTags — сколько меток можно повесить на одну операцию
+const maxTransactionTags = 20
+
+// GetAllTransactions возвращает страницу операций, подходящих под filter, и метаданные страницы
+func GetAllTransactions(userID uint, filter models.TransactionFilter, params models.ListParams) (transactions []models.Transaction, page models.PageInfo, err error) {
+	if filter, err = normalizeTransactionFilter(filter); err != nil {
+		return nil, page, err
+	}
+	params, err = normalizeListParams(params, models.SortByDate, models.SortByAmount, models.SortByCategory)
+	if err != nil {
+		return nil, page, err
+	}
+
+	transactions, total, err := repository.GetAllTransactions(userID, filter, params)
+	if err != nil {
+		return nil, page, err
+	}
+	if transactions == nil {
+		transactions = []models.Transaction{}
+	}
+	for i := range transactions {
+		localizeTransactionDate(&transactions[i])
+	}
+	return transactions, models.NewPageInfo(params, total), nil
+}
+
+func normalizeTransactionFilter(filter models.TransactionFilter) (models.TransactionFilter, error) {
+	filter.Type = strings.ToLower(strings.TrimSpace(filter.Type))
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	if filter.Currency != "" {
+		currency, err := normalizeCurrency(filter.Currency)
+		if err != nil {
+			return filter, err
+		}
+		filter.Currency = currency
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, errs.ErrValidationFailed
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, errs.ErrValidationFailed
+	}
+	return filter, nil
+}
+
+func GetTransactionByID(userID, transactionID uint) (transaction models.Transaction, err error) {
+	if err = authorizeTransaction(userID, transactionID); err != nil {
+		return transaction, err
+	}
+	transaction, err = repository.GetTransactionByID(userID, transactionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return transaction, errs.ErrOperationNotFound
+		}
+		return transaction, err
+	}
+	localizeTransactionDate(&transaction)
+	return transaction, nil
+}
+
+// CreateTransaction создаёт доход или расход. Переводы создаются только через /api/transfers.
+func CreateTransaction(transaction *models.Transaction, meta models.AuditMeta) error {
+	transaction.Type = strings.ToLower(strings.TrimSpace(transaction.Type))
+	if err := validateTransaction(transaction); err != nil {
+		return err
+	}
+	return repository.CreateTransaction(transaction, meta)
+}
+
+// UpdateTransaction заменяет сумму, описание, карту, категорию, дату и метки операции.
+// Тип операции не меняется, записи переводов изменяются только отменой перевода.
+func UpdateTransaction(transaction models.Transaction, meta models.AuditMeta) error {
+	existing, err := GetTransactionByID(transaction.UserID, transaction.ID)
+	if err != nil {
+		return err
+	}
+	if existing.Type == models.TransactionTypeTransfer {
+		return errs.ErrValidationFailed
+	}
+
+	transaction.Type = existing.Type
+	if transaction.Date.IsZero() {
+		transaction.Date = existing.Date
+	}
+	if transaction.TimeZone == "" {
+		transaction.TimeZone = existing.TimeZone
+	}
+	if err = validateTransaction(&transaction); err != nil {
+		return err
+	}
+	return repository.UpdateTransaction(transaction, meta)
+}
+
+func DeleteTransaction(transactionID, userID uint, meta models.AuditMeta) error {
+	existing, err := GetTransactionByID(userID, transactionID)
+	if err != nil {
+		return err
+	}
+	if existing.Type == models.TransactionTypeTransfer {
+		return errs.ErrValidationFailed
+	}
+	return repository.DeleteTransaction(transactionID, userID, meta)
+}
+
+// validateTransaction проверяет тип, сумму, карту и категорию операции и дополняет её:
+// валюта по умолчанию — валюта карты, дата по умолчанию — текущий момент
+func validateTransaction(transaction *models.Transaction) error {
+	categoryType := models.CategoryTypeOutcome
+	switch transaction.Type {
+	case models.TransactionTypeIncome:
+		categoryType = models.CategoryTypeIncome
+	case models.TransactionTypeExpense:
+		if transaction.CategoryID == nil {
+			return errs.NewFieldError("category_id", errs.FieldRequired, "is required for expenses")
+		}
+	case "":
+		return errs.NewFieldError("type", errs.FieldRequired, "is required")
+	default:
+		return errs.NewFieldError("type", errs.FieldInvalid, "must be one of: income, expense")
+	}
+
+	if transaction.Amount.Minor <= 0 {
+		return errs.NewFieldError("amount", errs.FieldTooSmall, "must be greater than 0")
+	}
+	if transaction.CardID != nil {
+		card, err := getActiveCard(transaction.UserID, *transaction.CardID)
+		if err != nil {
+			if errors.Is(err, errs.ErrOperationNotFound) {
+				return errs.NewFieldError("card_id", errs.FieldNotFound, "card not found")
+			}
+			if errors.Is(err, errs.ErrCardDeleted) {
+				return errs.WithField(err, "card_id", errs.FieldInvalid, "card is in the trash")
+			}
+			return err
+		}
+		if err = inCurrency(&transaction.Amount, card.Balance.Currency, "amount"); err != nil {
+			return err
+		}
+	}
+	if err := inCurrency(&transaction.Amount, models.DefaultCurrency, "amount"); err != nil {
+		return err
+	}
+
+	if transaction.CategoryID != nil {
+		if err := checkCategory(transaction.UserID, *transaction.CategoryID, categoryType); err != nil {
+			if errors.Is(err, errs.ErrValidationFailed) {
+				return errs.NewFieldError("category_id", errs.FieldNotFound, categoryType+" category not found")
+			}
+			return err
+		}
+	}
+
+	tags, err := normalizeTags(transaction.Tags)
+	if err != nil {
+		return errs.NewFieldError("tags", errs.FieldTooLong, "must have at most 20 tags of at most 64 characters")
+	}
+	transaction.Tags = tags
+
+	if transaction.TimeZone != "" {
+		if _, err = time.LoadLocation(transaction.TimeZone); err != nil {
+			return errs.NewFieldError("time_zone", errs.FieldInvalid, "must be an IANA time zone")
+		}
+	}
+	if transaction.Date.IsZero() {
+		transaction.Date = time.Now()
+	}
+	return nil
+}
+
+// localizeTransactionDate переводит дату операции в зону, в которой её указал клиент
+func localizeTransactionDate(transaction *models.Transaction) {
+	if transaction.TimeZone == "" {
+		return
+	}
+	if location, err := time.LoadLocation(transaction.TimeZone); err == nil {
+		transaction.Date = transaction.Date.In(location)
+	}
+}
+
+// normalizeTags приводит метки к нижнему регистру, убирает пустые и повторяющиеся
+func normalizeTags(tags models.Tags) (models.Tags, error) {
+	normalized := models.Tags{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > 64 {
+			return nil, errs.ErrValidationFailed
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTransactionTags {
+		return nil, errs.ErrValidationFailed
+	}
+	return normalized, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+)
+
+func GetAllTransfers(userID uint) (transfers []models.Transfer, err error) {
+	transfers, err = repository.GetAllTransfers(userID)
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func GetTransferByID(userID, transferID uint) (transfer models.Transfer, err error) {
+	if err = authorizeTransfer(userID, transferID); err != nil {
+		return models.Transfer{}, err
+	}
+	transfer, err = repository.GetTransferByID(userID, transferID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return transfer, errs.ErrOperationNotFound
+		}
+		return transfer, err
+	}
+	return transfer, nil
+}
+
+// CreateTransfer проверяет карты пользователя, пересчитывает сумму в валюту карты-получателя
+// и проводит перевод
+func CreateTransfer(transfer models.Transfer, meta models.AuditMeta) error {
+	if transfer.FromCardID == 0 {
+		return errs.NewFieldError("from_card_id", errs.FieldRequired, "is required")
+	}
+	if transfer.ToCardID == 0 {
+		return errs.NewFieldError("to_card_id", errs.FieldRequired, "is required")
+	}
+	if transfer.FromCardID == transfer.ToCardID {
+		return errs.NewFieldError("to_card_id", errs.FieldInvalid, "must differ from from_card_id")
+	}
+	if transfer.Amount.Minor <= 0 {
+		return errs.NewFieldError("amount", errs.FieldTooSmall, "must be greater than 0")
+	}
+	if transfer.Fee.IsNegative() {
+		return errs.NewFieldError("fee", errs.FieldTooSmall, "must be at least 0")
+	}
+
+	fromCard, err := GetCardByID(transfer.UserID, transfer.FromCardID)
+	if err != nil {
+		return err
+	}
+	toCard, err := GetCardByID(transfer.UserID, transfer.ToCardID)
+	if err != nil {
+		return err
+	}
+
+	// Сумма и комиссия списываются в валюте карты-источника
+	if err = inCurrency(&transfer.Amount, fromCard.Balance.Currency, "amount"); err != nil {
+		return err
+	}
+	if err = inCurrency(&transfer.Fee, fromCard.Balance.Currency, "fee"); err != nil {
+		return err
+	}
+	if transfer.Amount.Currency != fromCard.Balance.Currency {
+		return errs.WithField(errs.ErrCurrencyMismatch, "amount", errs.FieldInvalid, "must be in the card currency "+fromCard.Balance.Currency)
+	}
+	if transfer.Fee.Currency != fromCard.Balance.Currency {
+		return errs.WithField(errs.ErrCurrencyMismatch, "fee", errs.FieldInvalid, "must be in the card currency "+fromCard.Balance.Currency)
+	}
+
+	transfer.Credited, err = ConvertMoney(transfer.UserID, transfer.Amount, toCard.Balance.Currency)
+	if err != nil {
+		return err
+	}
+
+	transfer.ID = 0
+	transfer.Status = models.TransferStatusCompleted
+	transfer.CancelledAt = nil
+	return repository.CreateTransfer(transfer, meta)
+}
+
+func CancelTransfer(transferID, userID uint, meta models.AuditMeta) error {
+	if err := authorizeTransfer(userID, transferID); err != nil {
+		return err
+	}
+	err := repository.CancelTransfer(transferID, userID, meta)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
+		return err
+	}
+	return nil
+}
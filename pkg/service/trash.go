@@ -0,0 +1,114 @@
+package service
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/errs"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"errors"
+	"strings"
+	"time"
+)
+
+// GetTrash возвращает страницу корзины пользователя, сначала удалённые последними
+func GetTrash(userID uint, filter models.TrashFilter, params models.ListParams) (items []models.TrashItem, page models.PageInfo, err error) {
+	filter.EntityType = strings.ToLower(strings.TrimSpace(filter.EntityType))
+	switch filter.EntityType {
+	case "", models.TrashEntityTransaction, models.TrashEntityCard:
+	default:
+		return nil, page, errs.ErrValidationFailed
+	}
+	if params, err = normalizeListParams(params, models.SortByDate); err != nil {
+		return nil, page, err
+	}
+
+	items, total, err := repository.GetTrashPage(userID, filter, params)
+	if err != nil {
+		return nil, page, err
+	}
+	if items == nil {
+		items = []models.TrashItem{}
+	}
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.AddDate(0, 0, trashRetentionDays())
+	}
+	return items, models.NewPageInfo(params, total), nil
+}
+
+// RestoreTransaction возвращает из корзины доход или расход и снова проводит его по карте
+func RestoreTransaction(transactionID, userID uint, meta models.AuditMeta) error {
+	return restoreTransaction(userID, transactionID, meta, func(models.Transaction) bool { return true })
+}
+
+func RestoreIncome(incomeID, userID uint, meta models.AuditMeta) error {
+	return restoreTransaction(userID, incomeID, meta, func(t models.Transaction) bool {
+		return t.Type == models.TransactionTypeIncome
+	})
+}
+
+func RestoreOutcome(outcomeID, userID uint, meta models.AuditMeta) error {
+	return restoreTransaction(userID, outcomeID, meta, func(t models.Transaction) bool {
+		return t.Type == models.TransactionTypeExpense && t.CardID == nil
+	})
+}
+
+func RestoreExpense(expenseID, userID uint, meta models.AuditMeta) error {
+	return restoreTransaction(userID, expenseID, meta, func(t models.Transaction) bool {
+		return t.Type == models.TransactionTypeExpense && t.CardID != nil
+	})
+}
+
+// restoreTransaction восстанавливает операцию из корзины, если match подтверждает её вид.
+// Операцию по карте из корзины восстановить нельзя, пока не восстановлена сама карта.
+func restoreTransaction(userID, transactionID uint, meta models.AuditMeta, match func(models.Transaction) bool) error {
+	if err := authorizeTransaction(userID, transactionID); err != nil {
+		return err
+	}
+	transaction, err := repository.GetDeletedTransaction(userID, transactionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
+		return err
+	}
+	if !match(transaction) {
+		return errs.ErrOperationNotFound
+	}
+
+	if transaction.CardID != nil {
+		if _, err = GetCardByID(userID, *transaction.CardID); err != nil {
+			if errors.Is(err, errs.ErrOperationNotFound) {
+				return errs.ErrCardDeleted
+			}
+			return err
+		}
+	}
+	return repository.RestoreTransaction(transactionID, userID, meta)
+}
+
+// RestoreCard возвращает карту из корзины
+func RestoreCard(cardID, userID uint, meta models.AuditMeta) error {
+	if err := authorizeCard(userID, cardID); err != nil {
+		return err
+	}
+	if err := repository.RestoreCard(cardID, userID, meta); err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return errs.ErrOperationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// PurgeTrash окончательно удаляет записи, пролежавшие в корзине дольше срока хранения
+func PurgeTrash(now time.Time) (transactions, cards int64, err error) {
+	return repository.PurgeTrash(now.AddDate(0, 0, -trashRetentionDays()))
+}
+
+// trashRetentionDays — срок хранения записей в корзине из настроек, по умолчанию 30 дней
+func trashRetentionDays() int {
+	if days := configs.AppSettings.TrashParams.RetentionDays; days > 0 {
+		return days
+	}
+	return 30
+}
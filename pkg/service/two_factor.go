@@ -0,0 +1,255 @@
+package service
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/errs"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"coinkeeper/pkg/repository"
+	"coinkeeper/utils"
+	"errors"
+	"strings"
+	"time"
+)
+
+// recoveryCodeCount — сколько кодов восстановления выдаётся за раз
+const recoveryCodeCount = 10
+
+// GetTwoFactorStatus возвращает состояние двухфакторной аутентификации пользователя
+func GetTwoFactorStatus(userID uint) (status models.TwoFactorStatus, err error) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return status, err
+	}
+	status.Enabled = user.TwoFactorEnabled
+	status.Required = isTwoFactorRequired(user)
+	if user.TwoFactorEnabled {
+		if status.RecoveryCodesLeft, err = repository.CountUnusedRecoveryCodes(userID); err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// SetupTwoFactor создаёт новый секрет TOTP. Двухфакторная аутентификация включается только
+// после подтверждения секрета кодом из приложения в EnableTwoFactor.
+func SetupTwoFactor(userID uint) (setup models.TwoFactorSetup, err error) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return setup, err
+	}
+	if user.TwoFactorEnabled {
+		return setup, errs.ErrTwoFactorAlreadyEnabled
+	}
+
+	if setup.Secret, err = utils.GenerateTOTPSecret(); err != nil {
+		return setup, err
+	}
+	if err = repository.SetUserTOTPSecret(user.ID, setup.Secret); err != nil {
+		return setup, err
+	}
+	setup.ProvisioningURI = utils.TOTPProvisioningURI(setup.Secret, totpIssuer(), user.Username)
+	return setup, nil
+}
+
+// EnableTwoFactor подтверждает секрет из SetupTwoFactor кодом TOTP, включает двухфакторную
+// аутентификацию и возвращает коды восстановления. Коды показываются только один раз.
+func EnableTwoFactor(userID uint, code string) (codes []string, err error) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, errs.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, errs.ErrTwoFactorNotEnabled
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, normalizeTwoFactorCode(code), time.Now())
+	if !ok {
+		return nil, errs.ErrInvalidTwoFactorCode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = repository.EnableTwoFactor(user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor выключает двухфакторную аутентификацию после проверки пароля и второго фактора.
+// Пользователь, обязанный пользоваться двухфакторной аутентификацией, выключить её не может.
+func DisableTwoFactor(userID uint, password, code string) error {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return errs.ErrTwoFactorNotEnabled
+	}
+	if isTwoFactorRequired(user) {
+		return errs.ErrTwoFactorRequired
+	}
+
+	ok, _, err := utils.VerifyPassword(password, user.Password)
+	if err != nil || !ok {
+		return errs.ErrIncorrectPassword
+	}
+	if err = verifySecondFactor(user, code, true); err != nil {
+		return err
+	}
+	return repository.DisableTwoFactor(user.ID)
+}
+
+// RegenerateRecoveryCodes заменяет коды восстановления новыми после проверки кода TOTP
+func RegenerateRecoveryCodes(userID uint, code string) (codes []string, err error) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, errs.ErrTwoFactorNotEnabled
+	}
+	if err = verifySecondFactor(user, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = repository.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// SetTwoFactorRequired обязывает пользователя подключить двухфакторную аутентификацию
+// или снимает обязанность. Действует на токены, выданные после изменения.
+func SetTwoFactorRequired(userID uint, required bool) error {
+	if _, err := repository.GetUserByID(userID); err != nil {
+		return err
+	}
+	return repository.SetUserTwoFactorRequired(userID, required)
+}
+
+// SignInWithTwoFactor завершает вход, начатый SignIn, кодом TOTP или кодом восстановления.
+// Неверные коды считаются вместе с неудачными входами по паролю.
+func SignInWithTwoFactor(challengeToken, code string, client models.LoginClient) (tokens models.TokenPair, err error) {
+	challenge, err := repository.GetTwoFactorChallengeByHash(utils.GenerateHash(challengeToken))
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return tokens, errs.ErrInvalidToken
+		}
+		return tokens, err
+	}
+	if !challenge.ExpiresAt.After(time.Now()) || challenge.Attempts >= models.MaxTwoFactorAttempts {
+		return tokens, errs.ErrInvalidToken
+	}
+
+	user, err := repository.GetUserByID(challenge.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return tokens, errs.ErrInvalidToken
+		}
+		return tokens, err
+	}
+	if !user.TwoFactorEnabled {
+		return tokens, errs.ErrInvalidToken
+	}
+	if err = checkLoginLock(user.Username, &user.ID, client); err != nil {
+		return tokens, err
+	}
+
+	if err = verifySecondFactor(user, code, true); err != nil {
+		if errors.Is(err, errs.ErrInvalidTwoFactorCode) {
+			logger.Warn.Printf("[service.SignInWithTwoFactor] invalid two-factor code for user %d\n", user.ID)
+			if attemptErr := repository.AddTwoFactorChallengeAttempt(challenge.ID, models.MaxTwoFactorAttempts); attemptErr != nil {
+				return tokens, attemptErr
+			}
+			if attemptErr := registerLoginFailure(user.Username, &user.ID, client, models.LoginFailureInvalidTwoFactorCode); attemptErr != nil {
+				return tokens, attemptErr
+			}
+		}
+		return tokens, err
+	}
+	if err = repository.DeleteTwoFactorChallenge(challenge.ID); err != nil {
+		return tokens, err
+	}
+	resetLoginFailures(user.Username, client)
+	return startSession(user)
+}
+
+// startTwoFactorChallenge откладывает выдачу токенов до проверки второго фактора
+func startTwoFactorChallenge(user models.User) (result models.SignInResult, err error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return result, err
+	}
+	challenge := models.TwoFactorChallenge{
+		TokenHash: utils.GenerateHash(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(twoFactorChallengeTTL()),
+	}
+	if err = repository.CreateTwoFactorChallenge(&challenge); err != nil {
+		return result, err
+	}
+	result.TwoFactorRequired = true
+	result.ChallengeToken = token
+	return result, nil
+}
+
+// verifySecondFactor проверяет код TOTP, а если allowRecovery — и код восстановления.
+// Принятый код больше не принимается.
+func verifySecondFactor(user models.User, code string, allowRecovery bool) error {
+	code = normalizeTwoFactorCode(code)
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		return repository.UseTOTPStep(user.ID, step)
+	}
+	if allowRecovery && code != "" {
+		return repository.UseRecoveryCode(user.ID, utils.GenerateHash(strings.ReplaceAll(code, "-", "")), time.Now())
+	}
+	return errs.ErrInvalidTwoFactorCode
+}
+
+// isTwoFactorRequired — обязан ли пользователь пользоваться двухфакторной аутентификацией
+func isTwoFactorRequired(user models.User) bool {
+	return configs.AppSettings.AuthParams.RequireTwoFactor || user.TwoFactorRequired
+}
+
+// newRecoveryCodes возвращает коды восстановления вида xxxxx-xxxxx и их хеши для хранения;
+// дефис в хеш не входит, поэтому код принимается и без него
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		id, err := utils.RandomID(5)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, id[:5]+"-"+id[5:])
+		hashes = append(hashes, utils.GenerateHash(id))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeTwoFactorCode убирает пробелы, которыми приложения делят код, и приводит код к нижнему регистру
+func normalizeTwoFactorCode(code string) string {
+	return strings.ToLower(strings.Join(strings.Fields(code), ""))
+}
+
+func totpIssuer() string {
+	if issuer := configs.AppSettings.AuthParams.TotpIssuer; issuer != "" {
+		return issuer
+	}
+	return "CoinKeeper"
+}
+
+// twoFactorChallengeTTL — сколько ждать второй фактор после проверки пароля, по умолчанию 5 минут
+func twoFactorChallengeTTL() time.Duration {
+	if minutes := configs.AppSettings.AuthParams.TwoFactorChallengeTtlMinutes; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return 5 * time.Minute
+}
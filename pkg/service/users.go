@@ -6,20 +6,46 @@ import (
 	"coinkeeper/pkg/repository"
 	"coinkeeper/utils"
 	"errors"
+	"time"
 )
 
 func CreateUser(user models.User) error {
-	userFromDB, err := repository.GetUserByUsername(user.Username)
-	if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	if !isValidRole(user.Role) {
+		return errs.NewFieldError("role", errs.FieldInvalid, "must be one of: user, admin")
+	}
+	// Двухфакторная аутентификация включается только подтверждённым секретом TOTP
+	user.TwoFactorEnabled = false
+
+	taken, err := repository.IsUsernameTaken(user.Username)
+	if err != nil {
 		return err
 	}
-	if userFromDB.ID > 0 {
+	if taken {
 		return errs.ErrUsernameUniquenessFailed
 	}
+	if user.Locale, err = normalizeLocale(user.Locale); err != nil {
+		return err
+	}
+	if err = validateTimeZone(user.TimeZone); err != nil {
+		return err
+	}
+	if user.Email != "" {
+		if user.Email, err = normalizeEmail(user.Email); err != nil {
+			return err
+		}
+		if err = checkEmailAvailable(user.Email, 0); err != nil {
+			return err
+		}
+	}
 
-	user.Password = utils.GenerateHash(user.Password)
+	if user.Password, err = utils.HashPassword(user.Password); err != nil {
+		return err
+	}
 
-	err = repository.CreateUser(user)
+	err = repository.CreateUser(user, defaultCategories)
 	if err != nil {
 		return err
 	}
@@ -31,6 +57,9 @@ func GetAllUsers() (users []models.User, err error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range users {
+		users[i].Password = ""
+	}
 	return users, nil
 }
 
@@ -42,13 +71,82 @@ func GetUserByID(id uint) (user models.User, err error) {
 		}
 		return user, err
 	}
+	user.Password = ""
 	return user, nil
 }
 
-func DeleteUser(id uint) error {
-	err := repository.DeleteUser(id)
+// UpdateUser меняет имя, email, базовую валюту и роль пользователя; пустые поля не меняются.
+// Администратор actorID не может снять роль admin с самого себя.
+func UpdateUser(actorID uint, user models.User) error {
+	existing, err := repository.GetUserByID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if user.FullName != "" {
+		existing.FullName = user.FullName
+	}
+	if user.Email != "" {
+		if existing.Email, err = normalizeEmail(user.Email); err != nil {
+			return err
+		}
+		if err = checkEmailAvailable(existing.Email, existing.ID); err != nil {
+			return err
+		}
+	}
+	if user.BaseCurrency != "" {
+		if existing.BaseCurrency, err = normalizeCurrency(user.BaseCurrency); err != nil {
+			return errs.NewFieldError("base_currency", errs.FieldInvalid, "must be a three-letter ISO 4217 code")
+		}
+	}
+	if user.Role != "" {
+		if !isValidRole(user.Role) {
+			return errs.NewFieldError("role", errs.FieldInvalid, "must be one of: user, admin")
+		}
+		if existing.ID == actorID && user.Role != models.RoleAdmin {
+			return errs.NewFieldError("role", errs.FieldInvalid, "admins cannot remove their own admin role")
+		}
+		existing.Role = user.Role
+	}
+	return repository.UpdateUser(existing)
+}
+
+// DeleteUser удаляет пользователя так же, как DeleteAccount: сразу закрывает доступ,
+// а данные стираются после срока хранения. Администратор actorID не может удалить самого себя.
+func DeleteUser(actorID, id uint) error {
+	if actorID == id {
+		return errs.ErrValidationFailed
+	}
+	if _, err := repository.GetUserByID(id); err != nil {
+		return err
+	}
+	err := repository.DeleteUser(id, time.Now().Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PromoteAdmins выдаёт роль admin пользователям из настроек auth_params.admin_usernames
+func PromoteAdmins(usernames []string) error {
+	if len(usernames) == 0 {
+		return nil
+	}
+	return repository.SetUsersRole(usernames, models.RoleAdmin)
+}
+
+func isValidRole(role string) bool {
+	return role == models.RoleUser || role == models.RoleAdmin
+}
+
+// checkEmailAvailable проверяет, что email не занят другим пользователем, кроме userID
+func checkEmailAvailable(email string, userID uint) error {
+	taken, err := repository.IsEmailTaken(email, userID)
 	if err != nil {
 		return err
 	}
+	if taken {
+		return errs.ErrEmailUniquenessFailed
+	}
 	return nil
 }
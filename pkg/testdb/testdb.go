@@ -0,0 +1,110 @@
+// Package testdb готовит базу PostgreSQL для тестов репозитория и сервисов.
+//
+// База задаётся переменными окружения TEST_DB_NAME, TEST_DB_HOST, TEST_DB_PORT и TEST_DB_USER
+// (пароль, как и у приложения, — DB_PASSWORD). Без TEST_DB_NAME тесты с базой пропускаются.
+// Перед каждым тестом все таблицы базы очищаются, поэтому рабочую базу указывать нельзя.
+package testdb
+
+import (
+	"coinkeeper/configs"
+	"coinkeeper/db"
+	"coinkeeper/logger"
+	"coinkeeper/models"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var (
+	once     sync.Once
+	setupErr error
+)
+
+// Open подключается к тестовой базе и накатывает миграции (один раз за запуск тестов),
+// а затем очищает все таблицы. Если база не задана, тест пропускается.
+func Open(t *testing.T) {
+	t.Helper()
+	name := os.Getenv("TEST_DB_NAME")
+	if name == "" {
+		t.Skip("TEST_DB_NAME is not set, skipping test that needs PostgreSQL")
+	}
+
+	once.Do(func() { setupErr = connect(name) })
+	if setupErr != nil {
+		t.Fatalf("cannot prepare test database: %v", setupErr)
+	}
+	if err := truncate(); err != nil {
+		t.Fatalf("cannot clean test database: %v", err)
+	}
+}
+
+func connect(name string) error {
+	configs.AppSettings.PostgresParams = models.PostgresParams{
+		Host:     env("TEST_DB_HOST", "localhost"),
+		Port:     env("TEST_DB_PORT", "5432"),
+		User:     env("TEST_DB_USER", "postgres"),
+		Database: name,
+	}
+	discard := log.New(io.Discard, "", 0)
+	logger.Info, logger.Error, logger.Warn, logger.Debug = discard, discard, discard, discard
+
+	if err := db.ConnectToDB(); err != nil {
+		return err
+	}
+	db.GetDBConn().Logger = gormlogger.Discard
+	return db.Migrate()
+}
+
+func truncate() error {
+	var tables []string
+	err := db.GetDBConn().
+		Raw("SELECT quote_ident(tablename) FROM pg_tables WHERE schemaname = current_schema()").
+		Scan(&tables).Error
+	if err != nil || len(tables) == 0 {
+		return err
+	}
+	return db.GetDBConn().Exec(fmt.Sprintf("TRUNCATE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))).Error
+}
+
+func env(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// CreateUser сохраняет пользователя с логином username
+func CreateUser(t *testing.T, username string) models.User {
+	t.Helper()
+	user := models.User{FullName: username, Username: username, Password: "hash"}
+	if err := db.GetDBConn().Create(&user).Error; err != nil {
+		t.Fatalf("cannot create user %s: %v", username, err)
+	}
+	return user
+}
+
+// CreateCard сохраняет карту пользователя userID с балансом balance
+func CreateCard(t *testing.T, userID uint, balance models.Money) models.Card {
+	t.Helper()
+	card := models.Card{CardNumber: fmt.Sprintf("card of user %d", userID), Balance: balance, UserID: userID}
+	if err := db.GetDBConn().Create(&card).Error; err != nil {
+		t.Fatalf("cannot create card: %v", err)
+	}
+	return card
+}
+
+// CardBalance возвращает текущий баланс карты, в том числе удалённой
+func CardBalance(t *testing.T, cardID uint) models.Money {
+	t.Helper()
+	var card models.Card
+	if err := db.GetDBConn().Where("id = ?", cardID).First(&card).Error; err != nil {
+		t.Fatalf("cannot get card %d: %v", cardID, err)
+	}
+	return card.Balance
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule — разобранное cron-выражение из пяти полей: минута, час, день месяца, месяц, день недели
+type CronSchedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [32]bool
+	months   [13]bool
+	weekdays [7]bool
+
+	anyDay     bool
+	anyWeekday bool
+}
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// cronSearchLimit ограничивает поиск следующего срабатывания, чтобы выражения вроде "0 0 31 2 *" не зацикливались
+const cronSearchLimit = 5 * 366 * 24 * 60
+
+// ParseCron разбирает выражение вида "0 9 1 * *". Поддерживаются *, списки (1,15),
+// диапазоны (1-5) и шаги (*/2, 10-20/5). Воскресенье — 0 или 7.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidCron, len(fields))
+	}
+
+	schedule := &CronSchedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+
+	if err := parseCronField(fields[0], 0, 59, schedule.minutes[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[1], 0, 23, schedule.hours[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[2], 1, 31, schedule.days[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[3], 1, 12, schedule.months[:]); err != nil {
+		return nil, err
+	}
+
+	var weekdays [8]bool
+	if err := parseCronField(fields[4], 0, 7, weekdays[:]); err != nil {
+		return nil, err
+	}
+	copy(schedule.weekdays[:], weekdays[:7])
+	schedule.weekdays[0] = schedule.weekdays[0] || weekdays[7]
+
+	return schedule, nil
+}
+
+func parseCronField(field string, min, max int, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return fmt.Errorf("%w: bad step in %q", ErrInvalidCron, part)
+			}
+		}
+
+		from, to := min, max
+		if rangePart != "*" {
+			lo, hi, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = strconv.Atoi(lo); err != nil {
+				return fmt.Errorf("%w: bad value in %q", ErrInvalidCron, part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(hi); err != nil {
+					return fmt.Errorf("%w: bad range in %q", ErrInvalidCron, part)
+				}
+			} else if hasStep {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return fmt.Errorf("%w: %q is out of range %d-%d", ErrInvalidCron, part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// Next возвращает первое срабатывание строго после t (с точностью до минуты)
+// либо нулевое время, если в ближайшие пять лет срабатываний нет
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < cronSearchLimit; i++ {
+		if !s.months[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay повторяет правило cron: если ограничены и день месяца, и день недели,
+// достаточно совпадения любого из них
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayMatch := s.days[t.Day()]
+	weekdayMatch := s.weekdays[t.Weekday()]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekdayMatch
+	case s.anyWeekday:
+		return dayMatch
+	default:
+		return dayMatch || weekdayMatch
+	}
+}
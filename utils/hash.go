@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 )
 
+// GenerateHash возвращает SHA-256 строки в hex. Подходит для случайных токенов,
+// но не для паролей — пароли хешируются через HashPassword.
 func GenerateHash(input string) string {
 	hash := sha256.New()                   // Создаем новый SHA-256 хеш
 	hash.Write([]byte(input))              // Добавляем строку в хеш
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// Параметры argon2id для новых хешей паролей (рекомендация OWASP: 64 МиБ, 3 прохода)
+const (
+	argon2Memory  = 64 * 1024
+	argon2Time    = 3
+	argon2Threads = 2
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// HashPassword хеширует пароль argon2id со случайной солью и возвращает строку в формате PHC:
+// $argon2id$v=19$m=65536,t=3,p=2$<соль>$<хеш>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword сравнивает пароль с сохранённым хешем. Кроме argon2id понимает старые
+// несолёные SHA-256 хеши из GenerateHash. needsRehash означает, что хеш устарел
+// (SHA-256 или другие параметры argon2id) и его стоит пересчитать через HashPassword.
+func VerifyPassword(password, encoded string) (ok, needsRehash bool, err error) {
+	if !strings.HasPrefix(encoded, "$argon2id$") {
+		legacy := GenerateHash(password)
+		return subtle.ConstantTimeCompare([]byte(legacy), []byte(encoded)) == 1, true, nil
+	}
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var memory, iterations uint32
+	var threads uint8
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, false, ErrInvalidPasswordHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false, nil
+	}
+	needsRehash = memory != argon2Memory || iterations != argon2Time || threads != argon2Threads ||
+		len(salt) != argon2SaltLen || len(key) != argon2KeyLen
+	return true, needsRehash, nil
+}